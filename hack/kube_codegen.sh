@@ -435,6 +435,9 @@ function kube::codegen::gen_openapi() {
 #   --informers-name <string = "informers">
 #     An optional override for the leaf name of the generated "informers" directory.
 #
+#   --plural-exceptions <string = "Endpoints:Endpoints">
+#     An optional list of comma separated plural exception definitions in Type:PluralizedType form.
+#
 function kube::codegen::gen_client() {
     local in_pkg_root=""
     local out_pkg_root=""
@@ -446,6 +449,7 @@ function kube::codegen::gen_client() {
     local watchable="false"
     local listers_subdir="listers"
     local informers_subdir="informers"
+    local plural_exceptions="Endpoints:Endpoints"
     local boilerplate="${KUBE_CODEGEN_ROOT}/hack/boilerplate.go.txt"
     local v="${KUBE_VERBOSE:-0}"
 
@@ -495,6 +499,10 @@ function kube::codegen::gen_client() {
                 informers_subdir="$2"
                 shift 2
                 ;;
+            "--plural-exceptions")
+                plural_exceptions="$2"
+                shift 2
+                ;;
             *)
                 echo "unknown argument: $1" >&2
                 return 1
@@ -612,6 +620,7 @@ function kube::codegen::gen_client() {
         --output-base "${out_base}" \
         --output-package "${out_pkg_root}/${clientset_subdir}" \
         --apply-configuration-package "${applyconfig_pkg}" \
+        --plural-exceptions "${plural_exceptions}" \
         --input "${in_pkg_root}"
     set +x
     if [ "${watchable}" == "true" ]; then
@@ -632,6 +641,7 @@ function kube::codegen::gen_client() {
             --go-header-file "${boilerplate}" \
             --output-base "${out_base}" \
             --output-package "${out_pkg_root}/${listers_subdir}" \
+            --plural-exceptions "${plural_exceptions}" \
             "${inputs[@]}"
 
         echo "Generating informer code for ${#input_pkgs[@]} targets"
@@ -653,6 +663,7 @@ function kube::codegen::gen_client() {
             --output-package "${out_pkg_root}/${informers_subdir}" \
             --versioned-clientset-package "${out_pkg_root}/${clientset_subdir}/${clientset_versioned_name}" \
             --listers-package "${out_pkg_root}/${listers_subdir}" \
+            --plural-exceptions "${plural_exceptions}" \
             "${inputs[@]}"
     fi
 }
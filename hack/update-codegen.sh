@@ -48,6 +48,7 @@ kube::codegen::gen_helpers \
 
 kube::codegen::gen_client \
     --with-watch \
+    --plural-exceptions "Endpoints:Endpoints,DHParams:DHParams" \
     --input-pkg-root github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1 \
     --output-pkg-root github.com/jrodonnell/g8s/pkg/controller/generated \
     --output-base "$(dirname "${BASH_SOURCE[0]}")/../../../.." \
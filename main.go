@@ -56,8 +56,26 @@ func main() {
 	selfSignedTLSBundleInformer := g8sInformerFactory.Api().V1alpha1().SelfSignedTLSBundles()
 	loginInformer := g8sInformerFactory.Api().V1alpha1().Logins()
 	sshKeyPairInformer := g8sInformerFactory.Api().V1alpha1().SSHKeyPairs()
+	certificateAuthorityInformer := g8sInformerFactory.Api().V1alpha1().CertificateAuthorities()
+	certificateInformer := g8sInformerFactory.Api().V1alpha1().Certificates()
+	sshCertificateAuthorityInformer := g8sInformerFactory.Api().V1alpha1().SSHCertificateAuthorities()
+	jwtSigningKeyInformer := g8sInformerFactory.Api().V1alpha1().JWTSigningKeys()
+	randomSecretInformer := g8sInformerFactory.Api().V1alpha1().RandomSecrets()
+	apiTokenInformer := g8sInformerFactory.Api().V1alpha1().APITokens()
+	registryCredentialInformer := g8sInformerFactory.Api().V1alpha1().RegistryCredentials()
+	wireGuardKeyPairInformer := g8sInformerFactory.Api().V1alpha1().WireGuardKeyPairs()
+	pgpKeyPairInformer := g8sInformerFactory.Api().V1alpha1().PGPKeyPairs()
+	ageKeyPairInformer := g8sInformerFactory.Api().V1alpha1().AgeKeyPairs()
+	totpSeedInformer := g8sInformerFactory.Api().V1alpha1().TOTPSeeds()
+	dhParamsInformer := g8sInformerFactory.Api().V1alpha1().DHParams()
 	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 	secretInformer := kubeInformerFactory.Core().V1().Secrets()
+	certificateSigningRequestInformer := kubeInformerFactory.Certificates().V1().CertificateSigningRequests()
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	replicaSetInformer := kubeInformerFactory.Apps().V1().ReplicaSets()
+	deploymentInformer := kubeInformerFactory.Apps().V1().Deployments()
+	statefulSetInformer := kubeInformerFactory.Apps().V1().StatefulSets()
+	daemonSetInformer := kubeInformerFactory.Apps().V1().DaemonSets()
 
 	switch role {
 	case "controller":
@@ -66,8 +84,26 @@ func main() {
 			selfSignedTLSBundleInformer,
 			loginInformer,
 			sshKeyPairInformer,
+			certificateAuthorityInformer,
+			certificateInformer,
+			sshCertificateAuthorityInformer,
+			jwtSigningKeyInformer,
+			randomSecretInformer,
+			apiTokenInformer,
+			registryCredentialInformer,
+			wireGuardKeyPairInformer,
+			pgpKeyPairInformer,
+			ageKeyPairInformer,
+			totpSeedInformer,
+			dhParamsInformer,
 			namespaceInformer,
 			secretInformer,
+			certificateSigningRequestInformer,
+			podInformer,
+			replicaSetInformer,
+			deploymentInformer,
+			statefulSetInformer,
+			daemonSetInformer,
 		)
 
 		// notice that there is no need to run Start methods in a separate goroutine. (i.e. go kubeInformerFactory.Start(ctx.done())
@@ -80,20 +116,24 @@ func main() {
 			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 		}
 	case "webhook":
+		// the webhook only needs its own TLS bundle and the backend Secrets it injects, all in the g8s namespace
+		g8sSecretInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, time.Second*30, kubeinformers.WithNamespace("g8s")).Core().V1().Secrets()
+
 		allowlistInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    func(any) {},
 			UpdateFunc: func(old, new interface{}) {},
 			DeleteFunc: func(any) {},
 		})
+		go g8sSecretInformer.Informer().Run(ctx.Done())
 		g8sInformerFactory.Start(ctx.Done())
 
 		logger.Info("Waiting for Informer cache to sync...")
-		if ok := cache.WaitForCacheSync(ctx.Done(), allowlistInformer.Informer().HasSynced); !ok {
+		if ok := cache.WaitForCacheSync(ctx.Done(), allowlistInformer.Informer().HasSynced, g8sSecretInformer.Informer().HasSynced); !ok {
 			logger.Error(errors.New("error waiting for Informer cache to sync"), "failed to wait for caches to sync")
 		}
 		logger.Info("Done")
 
-		err := webhook.Serve(ctx, allowlistInformer)
+		err := webhook.Serve(ctx, allowlistInformer, g8sSecretInformer)
 
 		if err != nil {
 			logger.Error(err, "Error running server")
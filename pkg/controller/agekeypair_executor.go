@@ -0,0 +1,368 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runAgeKeyPairWorker is a long-running function that will continually call the
+// processNextAgeKeyPairWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runAgeKeyPairWorker(ctx context.Context) {
+	for c.processNextAgeKeyPairWorkItem(ctx) {
+	}
+}
+
+// processNextAgeKeyPairWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the ageKeyPairSyncHandler.
+func (c *Controller) processNextAgeKeyPairWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.ageKeyPairWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.ageKeyPairWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.ageKeyPairWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.ageKeyPairWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the ageKeyPairSyncHandler, passing it the namespace/name string of the
+		// AgeKeyPair resource to be synced.
+		if err := c.ageKeyPairSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.ageKeyPairWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.ageKeyPairWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// ageKeyPairSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the AgeKeyPair resource
+// with the current status of the resource.
+func (c *Controller) ageKeyPairSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the AgeKeyPair resource with this namespace/name
+	ageKeyPairFromLister, err := c.ageKeyPairLister.AgeKeyPairs(namespace).Get(name)
+	if err != nil {
+		// The AgeKeyPair resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("AgeKeyPair '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	ageKeyPair := ageKeyPairFromLister.DeepCopy()
+
+	backendName := "agekeypair-" + ageKeyPair.ObjectMeta.Name
+	historyName := "agekeypair-" + ageKeyPair.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(ageKeyPair.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, ageKeyPair.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sAgeKeyPair := internalv1alpha1.NewAgeKeyPair(ageKeyPair)
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sAgeKeyPair.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(ageKeyPair, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sAgeKeyPair.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(ageKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sAgeKeyPair, backendContent, ageKeyPairSecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(ageKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sAgeKeyPair, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sAgeKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), ageKeyPair.Status.LiveGeneration)
+		if content == nil {
+			content = g8sAgeKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(ageKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sAgeKeyPair, content, ageKeyPairSecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["identity-0"] = string(backend.Data["identity"])
+		content["recipient-0"] = string(backend.Data["recipient"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(ageKeyPair, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(ageKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sAgeKeyPair, content), metav1.CreateOptions{})
+		ageKeyPair.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this AgeKeyPair resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, ageKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(ageKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, ageKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(ageKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the AgeKeyPair ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     ageKeyPair,
+		g8s:        g8sAgeKeyPair,
+		workqueue:  c.ageKeyPairWorkqueue,
+		rotation:   ageKeyPair.Spec.Rotation,
+		history:    ageKeyPair.Spec.History,
+		status:     &ageKeyPair.Status.RotationStatus,
+		secretType: ageKeyPairSecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().AgeKeyPairs(ageKeyPair.Namespace).UpdateStatus(ctx, ageKeyPair, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*ageKeyPair = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Publish the recipient of the live generation, which rotations and rollbacks change
+	recipient := string(backend.Data["recipient"])
+	err = c.syncAgeRecipient(ctx, ageKeyPair, g8sAgeKeyPair, recipient)
+	if err != nil {
+		return err
+	}
+
+	ageKeyPair.Status.Recipient = recipient
+
+	// Finally, we update the status block of the AgeKeyPair resource to reflect the
+	// current state of the world
+	err = c.updateAgeKeyPairStatus(ageKeyPair)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(ageKeyPair, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// ageKeyPairSecretType is the type of an AgeKeyPair's backend Secret
+const ageKeyPairSecretType corev1.SecretType = "g8s.io/age-key-pair"
+
+// syncAgeRecipient publishes recipient in the recipient Secret of ageKeyPair,
+// replacing the Secret whenever it changes. This is the Secret Allowlists propagate
+// through ageRecipients, the backend Secret holds the identity.
+func (c *Controller) syncAgeRecipient(ctx context.Context, ageKeyPair *g8sv1alpha1.AgeKeyPair, g8sAgeKeyPair *internalv1alpha1.AgeKeyPair, recipient string) error {
+	logger := klog.FromContext(ctx)
+	name := "agekeypair-" + ageKeyPair.Name + "-recipient"
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(ageKeyPair.Namespace)
+
+	published, err := c.secretLister.Secrets(ageKeyPair.Namespace).Get(name)
+	if err == nil {
+		if !metav1.IsControlledBy(published, ageKeyPair) {
+			msg := fmt.Sprintf(MessageResourceExists, published.Name)
+			c.recorder.Event(ageKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if string(published.Data["recipient"]) == recipient {
+			return nil
+		}
+
+		// immutable like the backend Secret, so it has to be replaced rather than updated
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	logger.V(4).Info("Publish recipient Secret resource", "name", name)
+	_, err = secrets.Create(ctx, internalv1alpha1.NewAgeRecipientSecret(g8sAgeKeyPair, recipient), metav1.CreateOptions{})
+	return err
+}
+
+func (c *Controller) updateAgeKeyPairStatus(ageKeyPair *g8sv1alpha1.AgeKeyPair) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	ageKeyPairCopy := ageKeyPair.DeepCopy()
+	ageKeyPairCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the AgeKeyPair resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().AgeKeyPairs(ageKeyPair.Namespace).UpdateStatus(context.TODO(), ageKeyPairCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueAgeKeyPair takes an AgeKeyPair resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha AgeKeyPair.
+func (c *Controller) enqueueAgeKeyPair(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.ageKeyPairWorkqueue.Add(key)
+}
+
+// handleAgeKeyPairObject will take any resource implementing metav1.Object and attempt
+// to find the AgeKeyPair resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that AgeKeyPair resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleAgeKeyPairObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by an AgeKeyPair, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "AgeKeyPair" {
+			return
+		}
+
+		ageKeyPair, err := c.ageKeyPairLister.AgeKeyPairs(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "ageKeyPair", ownerRef.Name)
+			return
+		}
+
+		c.enqueueAgeKeyPair(ageKeyPair)
+		return
+	}
+}
+
+// Set up an event handler for when AgeKeyPair and/or their backend and history Secret resources change
+func (c *Controller) setAgeKeyPairInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.ageKeyPairInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueAgeKeyPair,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueAgeKeyPair(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			akp, ok := obj.(*g8sv1alpha1.AgeKeyPair)
+			if !ok {
+				logger.Error(nil, "obj is not an AgeKeyPair")
+			}
+			c.recorder.Event(akp, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when AgeKeyPair backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by an AgeKeyPair resource then the handler will enqueue that AgeKeyPair resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAgeKeyPairObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleAgeKeyPairObject(new)
+		},
+		DeleteFunc: c.handleAgeKeyPairObject,
+	})
+}
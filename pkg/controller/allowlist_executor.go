@@ -2,14 +2,18 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -87,6 +91,7 @@ func (c *Controller) processNextAllowlistWorkItem(ctx context.Context) bool {
 func (c *Controller) allowlistSyncHandler(ctx context.Context, key string) error {
 	// Convert the namespace/name string into a distinct namespace and name
 	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+	ctx = klog.NewContext(ctx, logger)
 
 	_, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -112,120 +117,37 @@ func (c *Controller) allowlistSyncHandler(ctx context.Context, key string) error
 
 	// target = map[namespace][]secretname
 	targets := make(map[string][]string)
-	for _, g := range g8sv1alpha1.G8sTypes {
-		switch g {
-		case "Logins":
-			for _, g := range allowlist.Spec.Logins {
-				for _, t := range g.Targets {
-					secretname := "login" + "-" + g.Name
-					targets[t.Namespace] = append(targets[t.Namespace], secretname)
-					sourceFromLister, err := c.secretLister.Secrets("g8s").Get(secretname)
-					if err != nil {
-						utilruntime.HandleError(fmt.Errorf("cannot find backend Secret '%s'", secretname))
-						return err
-					}
-
-					var targetSecret corev1.Secret
-					sourceFromLister.DeepCopyInto(&targetSecret)
-
-					// change certain ObjectMeta values, clear others
-					targetSecret.ObjectMeta.Namespace = t.Namespace
-					targetSecret.ObjectMeta.Labels = map[string]string{"owner": "g8s-master"}
-					targetSecret.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
-						*metav1.NewControllerRef(&allowlist.ObjectMeta, g8sv1alpha1.SchemeGroupVersion.WithKind("Allowlist")),
-					}
-
-					targetSecret.ObjectMeta.UID = ""
-					targetSecret.ObjectMeta.ResourceVersion = ""
-					targetSecret.ObjectMeta.CreationTimestamp = metav1.Time{Time: time.Time{}}
-
-					targetCheck, err := c.secretLister.Secrets(t.Namespace).Get(secretname)
-					if err != nil {
-						_, err = c.Client.kubeClientset.CoreV1().Secrets(t.Namespace).Create(ctx, &targetSecret, metav1.CreateOptions{})
-						if err != nil {
-							utilruntime.HandleError(fmt.Errorf("error mirroring Secret '%s' as specified in Allowlist '%s'", secretname, allowlist.ObjectMeta.Name))
-							return err
-						}
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' created", targetSecret.Name))
-					} else if targetCheck.OwnerReferences[0].Name == "g8s-master" {
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' already mirrored", targetSecret.Name))
-					}
+	// changed = map[namespace][]secretname, for copies replaced with new content
+	changed := make(map[string][]string)
+	// restart workloads that consume replaced copies, they won't see the new content
+	// otherwise. This also runs when a later copy fails to mirror, since the retry
+	// finds the copies replaced so far up to date and wouldn't restart them.
+	defer func() {
+		for namespace, secretnames := range changed {
+			c.restartWorkloads(ctx, allowlist, namespace, secretnames)
+		}
+	}()
+
+	for _, gt := range g8sv1alpha1.G8sTypes {
+		list := g8sv1alpha1.AllowlistFields[gt]
+		for _, g := range list.Targets(&allowlist.Spec) {
+			for _, t := range g.Targets {
+				// public-only lists propagate a Secret without the private half, which
+				// stays in the g8s namespace
+				secretname := list.SecretName(g.Name)
+				targets[t.Namespace] = append(targets[t.Namespace], secretname)
+				replaced, err := c.mirrorSecret(ctx, allowlist, secretname, t.Namespace)
+				if err != nil {
+					return err
 				}
-			}
-		case "SelfSignedTLSBundles":
-			for _, g := range allowlist.Spec.SelfSignedTLSBundles {
-				for _, t := range g.Targets {
-					secretname := "selfsignedtlsbundle" + "-" + g.Name
-					targets[t.Namespace] = append(targets[t.Namespace], secretname)
-					sourceFromLister, err := c.secretLister.Secrets("g8s").Get(secretname)
-					if err != nil {
-						utilruntime.HandleError(fmt.Errorf("cannot find backend Secret '%s'", secretname))
-						return err
-					}
-
-					var targetSecret corev1.Secret
-					sourceFromLister.DeepCopyInto(&targetSecret)
-
-					// change certain ObjectMeta values, clear others
-					targetSecret.Namespace = t.Namespace
-					targetSecret.ObjectMeta.Labels = map[string]string{"owner": "g8s-master"}
-					targetSecret.OwnerReferences = []metav1.OwnerReference{
-						*metav1.NewControllerRef(&allowlist.ObjectMeta, g8sv1alpha1.SchemeGroupVersion.WithKind("Allowlist")),
-					}
-
-					targetSecret.UID = ""
-					targetSecret.ResourceVersion = ""
-					targetSecret.CreationTimestamp = metav1.Time{Time: time.Time{}}
-
-					targetCheck, err := c.secretLister.Secrets(t.Namespace).Get(secretname)
-					if err != nil {
-						_, err = c.Client.kubeClientset.CoreV1().Secrets(t.Namespace).Create(ctx, &targetSecret, metav1.CreateOptions{})
-						if err != nil {
-							utilruntime.HandleError(fmt.Errorf("error mirroring Secret '%s' as specified in Allowlist '%s'", secretname, allowlist.ObjectMeta.Name))
-							return err
-						}
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' created", targetSecret.Name))
-					} else if targetCheck.OwnerReferences[0].Name == "g8s-master" {
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' already mirrored", targetSecret.Name))
-					}
+				if replaced && !slices.Contains(changed[t.Namespace], secretname) {
+					changed[t.Namespace] = append(changed[t.Namespace], secretname)
 				}
-			}
-		case "SSHKeyPairs":
-			for _, g := range allowlist.Spec.SSHKeyPairs {
-				for _, t := range g.Targets {
-					secretname := "sshkeypair" + "-" + g.Name
-					targets[t.Namespace] = append(targets[t.Namespace], secretname)
-					sourceFromLister, err := c.secretLister.Secrets("g8s").Get(secretname)
+				if gt == "RegistryCredentials" {
+					err = c.addImagePullSecret(ctx, g.Name, secretname, t.Namespace)
 					if err != nil {
-						utilruntime.HandleError(fmt.Errorf("cannot find backend Secret '%s'", secretname))
 						return err
 					}
-
-					var targetSecret corev1.Secret
-					sourceFromLister.DeepCopyInto(&targetSecret)
-
-					// change certain ObjectMeta values, clear others
-					targetSecret.Namespace = t.Namespace
-					targetSecret.ObjectMeta.Labels = map[string]string{"owner": "g8s-master"}
-					targetSecret.OwnerReferences = []metav1.OwnerReference{
-						*metav1.NewControllerRef(&allowlist.ObjectMeta, g8sv1alpha1.SchemeGroupVersion.WithKind("Allowlist")),
-					}
-
-					targetSecret.UID = ""
-					targetSecret.ResourceVersion = ""
-					targetSecret.CreationTimestamp = metav1.Time{Time: time.Time{}}
-
-					targetCheck, err := c.secretLister.Secrets(t.Namespace).Get(secretname)
-					if err != nil {
-						_, err = c.Client.kubeClientset.CoreV1().Secrets(t.Namespace).Create(ctx, &targetSecret, metav1.CreateOptions{})
-						if err != nil {
-							utilruntime.HandleError(fmt.Errorf("error mirroring Secret '%s' as specified in Allowlist '%s'", secretname, allowlist.ObjectMeta.Name))
-							return err
-						}
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' created", targetSecret.Name))
-					} else if targetCheck.OwnerReferences[0].Name == "g8s-master" {
-						logger.V(4).Info(fmt.Sprintf("target Secret '%s' already mirrored", targetSecret.Name))
-					}
 				}
 			}
 		}
@@ -274,6 +196,137 @@ func (c *Controller) allowlistSyncHandler(ctx context.Context, key string) error
 	return nil
 }
 
+// mirrorSecret copies the backend Secret secretname from the g8s namespace into
+// namespace. An existing copy is replaced when its content hash no longer matches
+// the source, e.g. because the source was rotated, in which case it returns true.
+func (c *Controller) mirrorSecret(ctx context.Context, allowlist *g8sv1alpha1.Allowlist, secretname, namespace string) (bool, error) {
+	logger := klog.FromContext(ctx)
+	sourceFromLister, err := c.secretLister.Secrets("g8s").Get(secretname)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("cannot find backend Secret '%s'", secretname))
+		return false, err
+	}
+
+	var targetSecret corev1.Secret
+	sourceFromLister.DeepCopyInto(&targetSecret)
+	hash := contentHash(sourceFromLister)
+
+	// change certain ObjectMeta values, clear others
+	targetSecret.Namespace = namespace
+	targetSecret.Labels = map[string]string{"owner": "g8s-master"}
+	if targetSecret.Annotations == nil {
+		targetSecret.Annotations = make(map[string]string)
+	}
+	targetSecret.Annotations[g8sv1alpha1.ContentHashAnnotation] = hash
+	targetSecret.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(&allowlist.ObjectMeta, g8sv1alpha1.SchemeGroupVersion.WithKind("Allowlist")),
+	}
+
+	targetSecret.UID = ""
+	targetSecret.ResourceVersion = ""
+	targetSecret.ManagedFields = nil
+	targetSecret.CreationTimestamp = metav1.Time{Time: time.Time{}}
+
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(namespace)
+	targetCheck, err := c.secretLister.Secrets(namespace).Get(secretname)
+	if errors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, &targetSecret, metav1.CreateOptions{})
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("error mirroring Secret '%s' as specified in Allowlist '%s'", secretname, allowlist.ObjectMeta.Name))
+			return false, err
+		}
+		logger.V(4).Info(fmt.Sprintf("target Secret '%s' created", targetSecret.Name))
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// never touch a Secret of the same name that we didn't create
+	if !metav1.IsControlledBy(targetCheck, allowlist) {
+		msg := fmt.Sprintf(MessageResourceExists, namespace+"/"+secretname)
+		c.recorder.Event(allowlist, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return false, nil
+	}
+
+	if targetCheck.Annotations[g8sv1alpha1.ContentHashAnnotation] == hash {
+		logger.V(4).Info(fmt.Sprintf("target Secret '%s' already mirrored", targetSecret.Name))
+		return false, nil
+	}
+
+	// the copy is immutable like its source, so it has to be replaced rather than updated
+	err = secrets.Delete(ctx, secretname, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	_, err = secrets.Create(ctx, &targetSecret, metav1.CreateOptions{})
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error mirroring Secret '%s' as specified in Allowlist '%s'", secretname, allowlist.ObjectMeta.Name))
+		return false, err
+	}
+	logger.V(4).Info(fmt.Sprintf("target Secret '%s' replaced", targetSecret.Name))
+
+	return true, nil
+}
+
+// addImagePullSecret adds the propagated copy secretname of the RegistryCredential
+// name to the imagePullSecrets of the ServiceAccounts it lists in namespace.
+// ServiceAccounts that don't exist yet are skipped, they get it on a later resync.
+func (c *Controller) addImagePullSecret(ctx context.Context, name, secretname, namespace string) error {
+	logger := klog.FromContext(ctx)
+	registryCredential, err := c.registryCredentialLister.RegistryCredentials("g8s").Get(name)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("cannot find RegistryCredential '%s'", name))
+		return err
+	}
+
+	// imagePullSecrets are merged by name, so the patch leaves the others in place
+	patch := []byte(fmt.Sprintf(`{"imagePullSecrets":[{"name":%q}]}`, secretname))
+	serviceAccounts := c.Client.kubeClientset.CoreV1().ServiceAccounts(namespace)
+	for _, sa := range registryCredential.Spec.ServiceAccounts {
+		serviceAccount, err := serviceAccounts.Get(ctx, sa, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			logger.V(4).Info("Skip missing ServiceAccount", "serviceAccount", sa, "namespace", namespace)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if slices.Contains(serviceAccount.ImagePullSecrets, corev1.LocalObjectReference{Name: secretname}) {
+			continue
+		}
+
+		_, err = serviceAccounts.Patch(ctx, sa, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("error adding imagePullSecret '%s' to ServiceAccount '%s/%s'", secretname, namespace, sa))
+			return err
+		}
+		logger.V(4).Info(fmt.Sprintf("imagePullSecret '%s' added to ServiceAccount '%s'", secretname, sa), "namespace", namespace)
+	}
+
+	return nil
+}
+
+// contentHash returns a digest of a Secret's type and data, used to tell whether a
+// propagated copy still matches its source.
+func contentHash(secret *corev1.Secret) string {
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	h.Write([]byte(secret.Type))
+	for _, k := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(secret.Data[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (c *Controller) updateAllowlistStatus(allowlist *g8sv1alpha1.Allowlist) error {
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	// You can use DeepCopy() to make a deep copy of original object and modify this copy
@@ -342,6 +395,50 @@ func (c *Controller) handleAllowlistObject(obj interface{}) {
 	}
 }
 
+// handleAllowlistSource will take any resource implementing metav1.Object and, if
+// it is the backend Secret of a g8s object in the g8s namespace, enqueue every
+// Allowlist so that propagated copies follow changes to their source.
+func (c *Controller) handleAllowlistSource(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+	}
+
+	if object.GetNamespace() != "g8s" {
+		return
+	}
+
+	// history Secrets are never propagated
+	if secret, ok := object.(*corev1.Secret); !ok || secret.Type == "g8s.io/history" {
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(object)
+	if ownerRef == nil || ownerRef.Kind == "Allowlist" || !strings.HasPrefix(ownerRef.APIVersion, g8sv1alpha1.GroupName+"/") {
+		return
+	}
+
+	allowlists, err := c.allowlistLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, allowlist := range allowlists {
+		c.enqueueAllowlist(allowlist)
+	}
+}
+
 // Set up an event handler for when Allowlist and/or their backend and history Secret resources change
 func (c *Controller) setAllowlistInformersEventHandlers(ctx context.Context) {
 	logger := klog.FromContext(ctx)
@@ -381,4 +478,20 @@ func (c *Controller) setAllowlistInformersEventHandlers(ctx context.Context) {
 		},
 		DeleteFunc: c.handleAllowlistObject,
 	})
+
+	// Set up an event handler for when the backend Secrets of g8s objects in the g8s
+	// namespace change, e.g. when they are rotated, so their propagated copies can be
+	// brought up to date.
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAllowlistSource,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				return
+			}
+			c.handleAllowlistSource(new)
+		},
+		DeleteFunc: c.handleAllowlistSource,
+	})
 }
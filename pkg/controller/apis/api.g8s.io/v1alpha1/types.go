@@ -22,7 +22,137 @@ import (
 
 type G8s []string
 
-var G8sTypes G8s = []string{"Logins", "SelfSignedTLSBundles", "SSHKeyPairs"}
+var G8sTypes G8s = []string{"Logins", "LoginHashes", "SelfSignedTLSBundles", "SSHKeyPairs", "CertificateAuthorities", "Certificates", "SSHCertificateAuthorities", "JWTSigningKeys", "JWTSigners", "RandomSecrets", "APITokens", "RegistryCredentials", "WireGuardKeyPairs", "PGPKeyPairs", "AgeKeyPairs", "AgeRecipients", "TOTPSeeds", "DHParams"}
+
+// AllowlistField describes one of the lists of an AllowlistSpec and the Secrets its
+// entries propagate
+// +k8s:deepcopy-gen=false
+type AllowlistField struct {
+	// Field is the JSON name of the list
+	Field string
+	// Prefix and Suffix surround the name of a g8s object in the name of the Secret
+	// propagated for it, the suffix naming a public-only Secret if set
+	Prefix string
+	Suffix string
+	// Targets returns the list from spec
+	Targets func(spec *AllowlistSpec) []G8sTargets
+}
+
+// SecretName returns the name of the Secret propagated for the g8s object name
+func (l AllowlistField) SecretName(name string) string {
+	return l.Prefix + name + l.Suffix
+}
+
+// AllowlistFields maps every type of G8sTypes to its list in an AllowlistSpec
+var AllowlistFields = map[string]AllowlistField{
+	"Logins":                    {Field: "logins", Prefix: "login-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.Logins }},
+	"LoginHashes":               {Field: "loginHashes", Prefix: "login-", Suffix: "-hashes", Targets: func(s *AllowlistSpec) []G8sTargets { return s.LoginHashes }},
+	"SelfSignedTLSBundles":      {Field: "selfSignedTLSBundles", Prefix: "selfsignedtlsbundle-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.SelfSignedTLSBundles }},
+	"SSHKeyPairs":               {Field: "sshKeyPairs", Prefix: "sshkeypair-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.SSHKeyPairs }},
+	"CertificateAuthorities":    {Field: "certificateAuthorities", Prefix: "certificateauthority-", Suffix: "-trust", Targets: func(s *AllowlistSpec) []G8sTargets { return s.CertificateAuthorities }},
+	"Certificates":              {Field: "certificates", Prefix: "certificate-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.Certificates }},
+	"SSHCertificateAuthorities": {Field: "sshCertificateAuthorities", Prefix: "sshcertificateauthority-", Suffix: "-trust", Targets: func(s *AllowlistSpec) []G8sTargets { return s.SSHCertificateAuthorities }},
+	"JWTSigningKeys":            {Field: "jwtSigningKeys", Prefix: "jwtsigningkey-", Suffix: "-jwks", Targets: func(s *AllowlistSpec) []G8sTargets { return s.JWTSigningKeys }},
+	"JWTSigners":                {Field: "jwtSigners", Prefix: "jwtsigningkey-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.JWTSigners }},
+	"RandomSecrets":             {Field: "randomSecrets", Prefix: "randomsecret-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.RandomSecrets }},
+	"APITokens":                 {Field: "apiTokens", Prefix: "apitoken-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.APITokens }},
+	"RegistryCredentials":       {Field: "registryCredentials", Prefix: "registrycredential-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.RegistryCredentials }},
+	"WireGuardKeyPairs":         {Field: "wireGuardKeyPairs", Prefix: "wireguardkeypair-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.WireGuardKeyPairs }},
+	"PGPKeyPairs":               {Field: "pgpKeyPairs", Prefix: "pgpkeypair-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.PGPKeyPairs }},
+	"AgeKeyPairs":               {Field: "ageKeyPairs", Prefix: "agekeypair-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.AgeKeyPairs }},
+	"AgeRecipients":             {Field: "ageRecipients", Prefix: "agekeypair-", Suffix: "-recipient", Targets: func(s *AllowlistSpec) []G8sTargets { return s.AgeRecipients }},
+	"TOTPSeeds":                 {Field: "totpSeeds", Prefix: "totpseed-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.TOTPSeeds }},
+	"DHParams":                  {Field: "dhParams", Prefix: "dhparams-", Targets: func(s *AllowlistSpec) []G8sTargets { return s.DHParams }},
+}
+
+const (
+	// RotateRequestedAtAnnotation requests an immediate rotation of a g8s object's
+	// backend Secret. Any new value, conventionally a timestamp, triggers one rotation.
+	RotateRequestedAtAnnotation = "g8s.io/rotate-requested-at"
+
+	// RollbackToAnnotation requests that a g8s object's backend Secret be restored
+	// to a generation of its history, where 0 is the newest generation. Any new
+	// value triggers one rollback, a timestamp after @ like 1@2024-05-01T12:00:00Z
+	// requests the same generation again.
+	RollbackToAnnotation = "g8s.io/rollback-to"
+
+	// ContentHashAnnotation is set on Secrets propagated by an Allowlist to a digest
+	// of their source's content, so that drifted copies can be replaced.
+	ContentHashAnnotation = "g8s.io/content-hash"
+
+	// AllowlistPodAnnotation is set by the mutating webhook on every Pod it injected
+	// Secrets into, to the name of the Allowlist it followed.
+	AllowlistPodAnnotation = "g8s-webhook/allowlist"
+
+	// WebhookAllowlist is the name of the Allowlist the mutating webhook follows.
+	WebhookAllowlist = "g8s-master"
+
+	// RestartedAtAnnotation is set on the Pod template of workloads restarted because
+	// a Secret they consume changed.
+	RestartedAtAnnotation = "g8s.io/restarted-at"
+
+	// SkipRestartAnnotation opts a Deployment, StatefulSet or DaemonSet out of being
+	// restarted when a Secret it consumes changes, if set to "true".
+	SkipRestartAnnotation = "g8s.io/skip-restart"
+
+	// RotateCARequestedAtAnnotation requests a phased rotation of the CA of a
+	// SelfSignedTLSBundle. Any new value, conventionally a timestamp, starts one.
+	RotateCARequestedAtAnnotation = "g8s.io/rotate-ca-requested-at"
+)
+
+// RotationSpec defines when the backend Secret of a g8s object is regenerated.
+// At most one of Interval or Schedule should be set, Schedule wins if both are.
+type RotationSpec struct {
+	// Interval is the time between rotations, e.g. 2160h for 90 days
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Schedule is a standard 5-field cron expression, e.g. "0 3 1 */3 *"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// HistorySpec limits how many generations the history Secret of a g8s object keeps.
+// Older generations are pruned when either limit is exceeded, the generation served
+// by the backend Secret is never pruned.
+type HistorySpec struct {
+	// MaxEntries is the maximum number of generations kept, including the newest
+	// +optional
+	MaxEntries int32 `json:"maxEntries,omitempty"`
+
+	// MaxAge is how long a generation is kept after it was created, e.g. 8760h
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// RotationStatus defines the observed rotation state of a g8s object
+type RotationStatus struct {
+	// +optional
+	LastRotated *metav1.Time `json:"lastRotated,omitempty"`
+
+	// +optional
+	NextRotation *metav1.Time `json:"nextRotation,omitempty"`
+
+	// LastRotationRequest is the last value of the g8s.io/rotate-requested-at
+	// annotation whose rotation replaced the backend and history Secrets
+	// +optional
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+
+	// LiveGeneration is the generation of the history currently served by the
+	// backend Secret, 0 unless the object was rolled back
+	// +optional
+	LiveGeneration int `json:"liveGeneration"`
+
+	// LastRollbackRequest is the last value of the g8s.io/rollback-to annotation
+	// that was acted upon
+	// +optional
+	LastRollbackRequest string `json:"lastRollbackRequest,omitempty"`
+
+	// RotationStarted is set while a rotation replaces the backend and history
+	// Secrets, and stays set after one that failed until a rotation succeeds
+	// +optional
+	RotationStarted *metav1.Time `json:"rotationStarted,omitempty"`
+}
 
 // +genclient
 // +genclient:nonNamespaced
@@ -44,11 +174,64 @@ type AllowlistSpec struct {
 	// +optional
 	Logins []G8sTargets `json:"logins,omitempty"`
 
+	// LoginHashes propagate only the username and password hashes of a Login, never
+	// its password
+	// +optional
+	LoginHashes []G8sTargets `json:"loginHashes,omitempty"`
+
 	// +optional
 	SelfSignedTLSBundles []G8sTargets `json:"selfSignedTLSBundles,omitempty"`
 
 	// +optional
 	SSHKeyPairs []G8sTargets `json:"sshKeyPairs,omitempty"`
+
+	// CertificateAuthorities propagate only the trust bundle of the CA, never its key
+	// +optional
+	CertificateAuthorities []G8sTargets `json:"certificateAuthorities,omitempty"`
+
+	// +optional
+	Certificates []G8sTargets `json:"certificates,omitempty"`
+
+	// SSHCertificateAuthorities propagate only the public keys of the CA, never its key
+	// +optional
+	SSHCertificateAuthorities []G8sTargets `json:"sshCertificateAuthorities,omitempty"`
+
+	// JWTSigningKeys propagate only the JWKS, never the signing key
+	// +optional
+	JWTSigningKeys []G8sTargets `json:"jwtSigningKeys,omitempty"`
+
+	// JWTSigners propagate the signing key of a JWTSigningKey along with its JWKS, to
+	// the services that issue tokens
+	// +optional
+	JWTSigners []G8sTargets `json:"jwtSigners,omitempty"`
+
+	// +optional
+	RandomSecrets []G8sTargets `json:"randomSecrets,omitempty"`
+
+	// +optional
+	APITokens []G8sTargets `json:"apiTokens,omitempty"`
+
+	// +optional
+	RegistryCredentials []G8sTargets `json:"registryCredentials,omitempty"`
+
+	// +optional
+	WireGuardKeyPairs []G8sTargets `json:"wireGuardKeyPairs,omitempty"`
+
+	// +optional
+	PGPKeyPairs []G8sTargets `json:"pgpKeyPairs,omitempty"`
+
+	// +optional
+	AgeKeyPairs []G8sTargets `json:"ageKeyPairs,omitempty"`
+
+	// AgeRecipients propagate only the recipient of an AgeKeyPair, never its identity
+	// +optional
+	AgeRecipients []G8sTargets `json:"ageRecipients,omitempty"`
+
+	// +optional
+	TOTPSeeds []G8sTargets `json:"totpSeeds,omitempty"`
+
+	// +optional
+	DHParams []G8sTargets `json:"dhParams,omitempty"`
 }
 
 type G8sTargets struct {
@@ -96,17 +279,47 @@ type Login struct {
 type LoginSpec struct {
 	Username string        `json:"username,omitempty"`
 	Password *PasswordSpec `json:"password,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
 }
 
 // PasswordSpec defines the desired state of Password
 type PasswordSpec struct {
 	Length       uint8  `json:"length,omitempty"`
 	CharacterSet string `json:"characterSet,omitempty"`
+
+	// Hashes of the password added to the backend Secret of a Login, each under the
+	// key of its name. Other types ignore them.
+	// +optional
+	Hashes []PasswordHash `json:"hashes,omitempty"`
 }
 
+// PasswordHash is a format the password of a Login is hashed in
+type PasswordHash string
+
+const (
+	// PasswordHashBcrypt is a $2a$ bcrypt hash
+	PasswordHashBcrypt PasswordHash = "bcrypt"
+	// PasswordHashArgon2id is an argon2id hash in the PHC string format
+	PasswordHashArgon2id PasswordHash = "argon2id"
+	// PasswordHashSHA512Crypt is a $6$ crypt(3) hash as used in /etc/shadow
+	PasswordHashSHA512Crypt PasswordHash = "sha512crypt"
+	// PasswordHashSCRAMSHA256 is a SCRAM-SHA-256 verifier as stored by Postgres
+	PasswordHashSCRAMSHA256 PasswordHash = "scram-sha-256"
+	// PasswordHashHtpasswd is an htpasswd line with the username and a bcrypt hash
+	PasswordHashHtpasswd PasswordHash = "htpasswd"
+)
+
 // LoginStatus defines the observed state of Login
 type LoginStatus struct {
 	Ready bool `json:"ready"`
+
+	// +optional
+	RotationStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -133,13 +346,216 @@ type SelfSignedTLSBundle struct {
 
 // SelfSignedTLSBundleSpec defines the desired state of SelfSignedTLSBundle
 type SelfSignedTLSBundleSpec struct {
+	LeafCertificateSpec `json:",inline"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	CARotation *CARotationSpec `json:"caRotation,omitempty"`
+
+	// SignerName makes the bundle's CA sign approved CertificateSigningRequests that
+	// name it, e.g. g8s.io/selfsigned. It has to be prefixed with g8s.io/.
+	// +optional
+	SignerName string `json:"signerName,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// LeafCertificateSpec defines the cert issued for a SelfSignedTLSBundle or Certificate
+type LeafCertificateSpec struct {
 	AppName string   `json:"appName,omitempty"`
 	SANs    []string `json:"sans,omitempty"`
+
+	// IPSANs are IP addresses added to the cert's SANs
+	// +optional
+	IPSANs []string `json:"ipSANs,omitempty"`
+
+	// URISANs are URIs added to the cert's SANs, e.g. SPIFFE IDs
+	// +optional
+	URISANs []string `json:"uriSANs,omitempty"`
+
+	// KeyAlgorithm of the cert key, and of the CA key of a SelfSignedTLSBundle,
+	// ecdsa-p256 by default
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// Usages are the extended key usages of the cert, server auth by default
+	// +optional
+	Usages []KeyUsage `json:"usages,omitempty"`
+
+	// Subject fields besides the common name, which is always AppName
+	// +optional
+	Subject *SubjectSpec `json:"subject,omitempty"`
+
+	// OutputFormat is the layout of the backend Secret, pem by default
+	// +optional
+	OutputFormat TLSOutputFormat `json:"outputFormat,omitempty"`
+
+	// CertManagerAnnotations adds the annotations cert-manager sets on the Secrets
+	// it issues to the backend Secret, for tools that look for them
+	// +optional
+	CertManagerAnnotations bool `json:"certManagerAnnotations,omitempty"`
+
+	// Duration is how long issued certs are valid for, 8760h (365 days) by default
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry a cert is reissued, a third of Duration
+	// by default
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Keystores adds keystores with the key and certs to the backend Secret, for JVM
+	// services that can't read PEM files
+	// +optional
+	Keystores *KeystoresSpec `json:"keystores,omitempty"`
+}
+
+// KeystoresSpec defines the keystores written to a TLS bundle's backend Secret next
+// to the PEM files
+type KeystoresSpec struct {
+	// Formats of the keystores. Each format adds a keystore with the key and its cert
+	// chain and a truststore with the CA certs.
+	Formats []KeystoreFormat `json:"formats,omitempty"`
+
+	// Alias of the key in the keystores, the appName by default
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// Password of the stores and of the key in them, generated like the password of a
+	// Login and written to keystore.password
+	// +optional
+	Password *PasswordSpec `json:"password,omitempty"`
+}
+
+// KeystoreFormat is the file format of a TLS bundle's keystores
+type KeystoreFormat string
+
+const (
+	// KeystoreFormatPKCS12 writes keystore.p12 and truststore.p12
+	KeystoreFormatPKCS12 KeystoreFormat = "pkcs12"
+	// KeystoreFormatJKS writes keystore.jks and truststore.jks
+	KeystoreFormatJKS KeystoreFormat = "jks"
+)
+
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ecdsa-p256"
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ecdsa-p384"
+	KeyAlgorithmRSA2048   KeyAlgorithm = "rsa-2048"
+	KeyAlgorithmRSA4096   KeyAlgorithm = "rsa-4096"
+	KeyAlgorithmEd25519   KeyAlgorithm = "ed25519"
+)
+
+// TLSOutputFormat is the layout of a TLS bundle's backend Secret
+type TLSOutputFormat string
+
+const (
+	// OutputFormatPEM writes key.pem, cert.pem and cacert.pem
+	OutputFormatPEM TLSOutputFormat = "pem"
+	// OutputFormatKubernetesTLS writes a kubernetes.io/tls Secret with tls.key,
+	// tls.crt and ca.crt
+	OutputFormatKubernetesTLS TLSOutputFormat = "kubernetes.io/tls"
+)
+
+type KeyUsage string
+
+const (
+	UsageServerAuth KeyUsage = "server auth"
+	UsageClientAuth KeyUsage = "client auth"
+)
+
+// SubjectSpec defines the subject fields of a cert
+type SubjectSpec struct {
+	// Organizations default to g8s when no subject is given
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+
+	// Countries are two-letter ISO 3166 country codes
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+}
+
+// CARotationSpec defines how the CA of a SelfSignedTLSBundle is replaced. The new
+// CA is first published next to the old one in cacert.pem, after Overlap the cert is
+// reissued by the new CA, and after another Overlap the old CA is dropped.
+type CARotationSpec struct {
+	// Overlap is how long each phase of a CA rotation lasts, 24h by default
+	// +optional
+	Overlap *metav1.Duration `json:"overlap,omitempty"`
+}
+
+type CARotationPhase string
+
+const (
+	// CARotationTrustPublished means cacert.pem holds the new and the old CA, and the
+	// cert is still signed by the old one
+	CARotationTrustPublished CARotationPhase = "TrustPublished"
+
+	// CARotationLeafSwitched means the cert is signed by the new CA, and cacert.pem
+	// still holds the old one
+	CARotationLeafSwitched CARotationPhase = "LeafSwitched"
+
+	// CARotationComplete means cacert.pem only holds the new CA
+	CARotationComplete CARotationPhase = "Complete"
+)
+
+// CARotationStatus tracks the progress of the latest CA rotation of a SelfSignedTLSBundle
+type CARotationStatus struct {
+	Phase CARotationPhase `json:"phase,omitempty"`
+
+	// +optional
+	TrustPublishedAt *metav1.Time `json:"trustPublishedAt,omitempty"`
+
+	// +optional
+	LeafSwitchedAt *metav1.Time `json:"leafSwitchedAt,omitempty"`
+
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// LastRequest is the last value of the rotate-ca-requested-at annotation that
+	// was acted upon
+	// +optional
+	LastRequest string `json:"lastRequest,omitempty"`
 }
 
 // SelfSignedTLSBundleStatus defines the observed state of SelfSignedTLSBundle
 type SelfSignedTLSBundleStatus struct {
 	Ready bool `json:"ready"`
+
+	// +optional
+	CertificateValidity `json:",inline"`
+
+	// +optional
+	CARotation *CARotationStatus `json:"caRotation,omitempty"`
+
+	// SignerConflict names the object that signs for spec.signerName instead of
+	// this one, because it claimed the signerName first
+	// +optional
+	SignerConflict string `json:"signerConflict,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// CertificateValidity is the validity of the cert in a backend Secret
+type CertificateValidity struct {
+	// NotBefore and NotAfter are parsed from the cert in the backend Secret
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is when the cert in the backend Secret will be reissued
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -155,6 +571,16 @@ type SSHKeyPairType string
 const (
 	RSA     SSHKeyPairType = "rsa"
 	Ed25519 SSHKeyPairType = "ed25519"
+	ECDSA   SSHKeyPairType = "ecdsa"
+)
+
+// SSHKeyPairCurve is the elliptic curve of an ecdsa SSHKeyPair
+type SSHKeyPairCurve string
+
+const (
+	CurveP256 SSHKeyPairCurve = "p256"
+	CurveP384 SSHKeyPairCurve = "p384"
+	CurveP521 SSHKeyPairCurve = "p521"
 )
 
 // +genclient
@@ -177,11 +603,51 @@ type SSHKeyPairSpec struct {
 	BitSize int `json:"bitSize,omitempty"`
 
 	KeyType SSHKeyPairType `json:"keyType,omitempty"`
+
+	// Curve of an ecdsa key, p256 by default
+	// +optional
+	Curve SSHKeyPairCurve `json:"curve,omitempty"`
+
+	// Comment is appended to the public key in ssh.pub, e.g. riley@example.com
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// Passphrase has the private key encrypted with a passphrase generated like the
+	// password of a Login, which is written to ssh.passphrase
+	// +optional
+	Passphrase *PasswordSpec `json:"passphrase,omitempty"`
+
+	// Certificate has the public key signed by an SSHCertificateAuthority, the
+	// OpenSSH certificate is written to ssh-cert.pub
+	// +optional
+	Certificate *SSHCertificateSpec `json:"certificate,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
 }
 
 // SSHKeyPairStatus defines the observed state of SSHKeyPair
 type SSHKeyPairStatus struct {
 	Ready bool `json:"ready"`
+
+	// Fingerprint is the SHA256 fingerprint of the public key in the backend Secret
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Validity of the certificate in ssh-cert.pub, if there is one
+	// +optional
+	CertificateValidity `json:",inline"`
+
+	// CertificateError is why the last certificate couldn't be issued, cleared once
+	// one is
+	// +optional
+	CertificateError string `json:"certificateError,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -191,3 +657,792 @@ type SSHKeyPairList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []SSHKeyPair `json:"items"`
 }
+
+type SSHCertificateType string
+
+const (
+	SSHUserCertificate SSHCertificateType = "user"
+	SSHHostCertificate SSHCertificateType = "host"
+)
+
+// SSHCertificateSpec defines the OpenSSH certificate issued for an SSHKeyPair
+type SSHCertificateSpec struct {
+	// CertificateAuthorityRef is the name of the SSHCertificateAuthority in the same
+	// namespace that signs the certificate
+	CertificateAuthorityRef string `json:"certificateAuthorityRef,omitempty"`
+
+	// Type of the certificate, user by default
+	// +optional
+	Type SSHCertificateType `json:"type,omitempty"`
+
+	// KeyID identifies the certificate in sshd logs, $NAMESPACE/$NAME by default
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Principals are the user names or host names the certificate is valid for, any
+	// if empty
+	// +optional
+	Principals []string `json:"principals,omitempty"`
+
+	// Duration is how long the certificate is valid for, 720h by default
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry the certificate is reissued, a third of
+	// Duration by default
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// CriticalOptions like force-command or source-address
+	// +optional
+	CriticalOptions map[string]string `json:"criticalOptions,omitempty"`
+
+	// Extensions like permit-pty, user certificates get the same ones as from
+	// ssh-keygen by default
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// SSHCertificateAuthority is the Schema for the SSHCertificateAuthorities API
+type SSHCertificateAuthority struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSHCertificateAuthoritySpec   `json:"spec,omitempty"`
+	Status SSHCertificateAuthorityStatus `json:"status,omitempty"`
+}
+
+// SSHCertificateAuthoritySpec defines the desired state of SSHCertificateAuthority
+type SSHCertificateAuthoritySpec struct {
+	// KeyType of the CA key, ed25519 by default
+	// +optional
+	KeyType SSHKeyPairType `json:"keyType,omitempty"`
+
+	// +optional
+	BitSize int `json:"bitSize,omitempty"`
+
+	// Curve of an ecdsa key, p256 by default
+	// +optional
+	Curve SSHKeyPairCurve `json:"curve,omitempty"`
+
+	// HostPatterns the @cert-authority lines in known_hosts apply to, * by default
+	// +optional
+	HostPatterns []string `json:"hostPatterns,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// SSHCertificateAuthorityStatus defines the observed state of SSHCertificateAuthority
+type SSHCertificateAuthorityStatus struct {
+	Ready bool `json:"ready"`
+
+	// Fingerprint is the SHA256 fingerprint of the CA key in the backend Secret
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// SSHCertificateAuthorityList contains a list of SSHCertificateAuthority
+type SSHCertificateAuthorityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHCertificateAuthority `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// CertificateAuthority is the Schema for the CertificateAuthorities API
+type CertificateAuthority struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateAuthoritySpec   `json:"spec,omitempty"`
+	Status CertificateAuthorityStatus `json:"status,omitempty"`
+}
+
+// CertificateAuthoritySpec defines the desired state of CertificateAuthority
+type CertificateAuthoritySpec struct {
+	CommonName string `json:"commonName,omitempty"`
+
+	// Subject fields besides the common name
+	// +optional
+	Subject *SubjectSpec `json:"subject,omitempty"`
+
+	// KeyAlgorithm of the CA key, ecdsa-p256 by default
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// Duration is how long the CA cert is valid for, 87600h (10 years) by default
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry the CA is regenerated, a third of Duration
+	// by default
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// SignerName makes the CA sign approved CertificateSigningRequests that name it,
+	// e.g. g8s.io/mesh. It has to be prefixed with g8s.io/.
+	// +optional
+	SignerName string `json:"signerName,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// CertificateAuthorityStatus defines the observed state of CertificateAuthority
+type CertificateAuthorityStatus struct {
+	Ready bool `json:"ready"`
+
+	// +optional
+	CertificateValidity `json:",inline"`
+
+	// SignerConflict names the object that signs for spec.signerName instead of
+	// this one, because it claimed the signerName first
+	// +optional
+	SignerConflict string `json:"signerConflict,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// CertificateAuthorityList contains a list of CertificateAuthority
+type CertificateAuthorityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateAuthority `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// Certificate is the Schema for the Certificates API
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec,omitempty"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// CertificateSpec defines the desired state of Certificate
+type CertificateSpec struct {
+	// CertificateAuthorityRef is the name of the CertificateAuthority in the same
+	// namespace that issues the cert
+	CertificateAuthorityRef string `json:"certificateAuthorityRef,omitempty"`
+
+	LeafCertificateSpec `json:",inline"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// CertificateStatus defines the observed state of Certificate
+type CertificateStatus struct {
+	Ready bool `json:"ready"`
+
+	// +optional
+	CertificateValidity `json:",inline"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// CertificateList contains a list of Certificate
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
+
+// JWTAlgorithm is the JWS algorithm a JWTSigningKey signs with
+type JWTAlgorithm string
+
+const (
+	JWTAlgorithmRS256 JWTAlgorithm = "RS256"
+	JWTAlgorithmES256 JWTAlgorithm = "ES256"
+	JWTAlgorithmEdDSA JWTAlgorithm = "EdDSA"
+)
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// JWTSigningKey is the Schema for the JWTSigningKeys API
+type JWTSigningKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JWTSigningKeySpec   `json:"spec,omitempty"`
+	Status JWTSigningKeyStatus `json:"status,omitempty"`
+}
+
+// JWTSigningKeySpec defines the desired state of JWTSigningKey
+type JWTSigningKeySpec struct {
+	// Algorithm the key signs with, RS256 by default
+	// +optional
+	Algorithm JWTAlgorithm `json:"algorithm,omitempty"`
+
+	// BitSize of RS256 keys, 2048 by default
+	// +optional
+	BitSize int `json:"bitSize,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// History also bounds how many previous keys are published in the JWKS
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// JWTSigningKeyStatus defines the observed state of JWTSigningKey
+type JWTSigningKeyStatus struct {
+	Ready bool `json:"ready"`
+
+	// KeyID is the kid of the key in the backend Secret
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// JWTSigningKeyList contains a list of JWTSigningKey
+type JWTSigningKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JWTSigningKey `json:"items"`
+}
+
+// RandomSecretEncoding is how the random bytes of a RandomSecret are written out
+type RandomSecretEncoding string
+
+const (
+	EncodingHex       RandomSecretEncoding = "hex"
+	EncodingBase64    RandomSecretEncoding = "base64"
+	EncodingBase64URL RandomSecretEncoding = "base64url"
+	EncodingBase32    RandomSecretEncoding = "base32"
+	// EncodingAlphanumeric draws Bytes characters from [a-zA-Z0-9] instead of encoding
+	// random bytes
+	EncodingAlphanumeric RandomSecretEncoding = "alphanumeric"
+)
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// RandomSecret is the Schema for the RandomSecrets API
+type RandomSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RandomSecretSpec   `json:"spec,omitempty"`
+	Status RandomSecretStatus `json:"status,omitempty"`
+}
+
+// RandomSecretSpec defines the desired state of RandomSecret
+type RandomSecretSpec struct {
+	// Bytes is the number of random bytes, or characters for alphanumeric, 32 by default
+	// +optional
+	Bytes int `json:"bytes,omitempty"`
+
+	// Encoding of the random bytes, base64 by default
+	// +optional
+	Encoding RandomSecretEncoding `json:"encoding,omitempty"`
+
+	// Keys are the keys of the backend Secret, a single key called secret by default.
+	// Changes to the keys take effect at the next rotation.
+	// +optional
+	Keys []RandomSecretKey `json:"keys,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// RandomSecretKey defines a key of a RandomSecret's backend Secret
+type RandomSecretKey struct {
+	Name string `json:"name,omitempty"`
+
+	// Bytes overrides spec.bytes for this key
+	// +optional
+	Bytes int `json:"bytes,omitempty"`
+
+	// Encoding overrides spec.encoding for this key
+	// +optional
+	Encoding RandomSecretEncoding `json:"encoding,omitempty"`
+}
+
+// RandomSecretStatus defines the observed state of RandomSecret
+type RandomSecretStatus struct {
+	Ready bool `json:"ready"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// RandomSecretList contains a list of RandomSecret
+type RandomSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RandomSecret `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// APIToken is the Schema for the APITokens API
+type APIToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APITokenSpec   `json:"spec,omitempty"`
+	Status APITokenStatus `json:"status,omitempty"`
+}
+
+// APITokenSpec defines the desired state of APIToken. Tokens look like
+// $PREFIX_$RANDOM$CHECKSUM, where the checksum is the CRC32 of the random part, so
+// that secret scanners can recognize them without false positives.
+type APITokenSpec struct {
+	// Prefix identifies the issuer of the token, e.g. acme for acme_... tokens
+	Prefix string `json:"prefix,omitempty"`
+
+	// Length is the number of random characters, 30 by default
+	// +optional
+	Length int `json:"length,omitempty"`
+
+	// GracePeriod is how long the previous token stays valid after a rotation, 24h by
+	// default
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// APITokenStatus defines the observed state of APIToken
+type APITokenStatus struct {
+	Ready bool `json:"ready"`
+
+	// GracePeriodEnd is when previous tokens stop being listed in valid-digests
+	// +optional
+	GracePeriodEnd *metav1.Time `json:"gracePeriodEnd,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// APITokenList contains a list of APIToken
+type APITokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIToken `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// RegistryCredential is the Schema for the RegistryCredentials API
+type RegistryCredential struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegistryCredentialSpec   `json:"spec,omitempty"`
+	Status RegistryCredentialStatus `json:"status,omitempty"`
+}
+
+// RegistryCredentialSpec defines the desired state of RegistryCredential
+type RegistryCredentialSpec struct {
+	// Server is the registry the credential is for, e.g. registry.example.com
+	Server   string        `json:"server,omitempty"`
+	Username string        `json:"username,omitempty"`
+	Password *PasswordSpec `json:"password,omitempty"`
+
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// ServiceAccounts get the propagated Secret added to their imagePullSecrets in
+	// every namespace an Allowlist propagates it to
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// RegistryCredentialStatus defines the observed state of RegistryCredential
+type RegistryCredentialStatus struct {
+	Ready bool `json:"ready"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// RegistryCredentialList contains a list of RegistryCredential
+type RegistryCredentialList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegistryCredential `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// WireGuardKeyPair is the Schema for the WireGuardKeyPairs API
+type WireGuardKeyPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WireGuardKeyPairSpec   `json:"spec,omitempty"`
+	Status WireGuardKeyPairStatus `json:"status,omitempty"`
+}
+
+// WireGuardKeyPairSpec defines the desired state of WireGuardKeyPair
+type WireGuardKeyPairSpec struct {
+	// PresharedKey adds a preshared key that is mixed into the handshake with peers
+	// +optional
+	PresharedKey bool `json:"presharedKey,omitempty"`
+
+	// Address is the address of the interface in wg0.conf, e.g. 10.8.0.1/24
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// ListenPort is the port the interface in wg0.conf listens on
+	// +optional
+	ListenPort int32 `json:"listenPort,omitempty"`
+
+	// Endpoint is where peers that reference this WireGuardKeyPair reach it, e.g.
+	// node-a.example.com:51820
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// AllowedIPs are routed to this WireGuardKeyPair by peers that reference it
+	// +optional
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+
+	// Peers are WireGuardKeyPairs in the same namespace, if set a wg0.conf with a
+	// [Peer] section for each of them is rendered
+	// +optional
+	Peers []string `json:"peers,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// WireGuardKeyPairStatus defines the observed state of WireGuardKeyPair
+type WireGuardKeyPairStatus struct {
+	Ready bool `json:"ready"`
+
+	// PublicKey is the public key of the live generation
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// WireGuardKeyPairList contains a list of WireGuardKeyPair
+type WireGuardKeyPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WireGuardKeyPair `json:"items"`
+}
+
+// PGPKeyPairAlgorithm is the public key algorithm of a PGPKeyPair
+type PGPKeyPairAlgorithm string
+
+const (
+	PGPAlgorithmEd25519 PGPKeyPairAlgorithm = "ed25519"
+	PGPAlgorithmRSA     PGPKeyPairAlgorithm = "rsa"
+)
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// PGPKeyPair is the Schema for the PGPKeyPairs API
+type PGPKeyPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PGPKeyPairSpec   `json:"spec,omitempty"`
+	Status PGPKeyPairStatus `json:"status,omitempty"`
+}
+
+// PGPKeyPairSpec defines the desired state of PGPKeyPair
+type PGPKeyPairSpec struct {
+	// Name and Email make up the user ID of the key, e.g. Riley <riley@example.com>
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// Algorithm of the key, ed25519 by default
+	// +optional
+	Algorithm PGPKeyPairAlgorithm `json:"algorithm,omitempty"`
+
+	// BitSize of an rsa key, 4096 by default
+	// +optional
+	BitSize int `json:"bitSize,omitempty"`
+
+	// Expiry is how long the key is valid for after it's created or renewed, 17520h
+	// (2 years) by default, 0s for a key that doesn't expire
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
+
+	// RenewBefore is how long before expiry the key is renewed, a third of Expiry by
+	// default
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Passphrase has the private key encrypted with a passphrase generated like the
+	// password of a Login, which is written to passphrase
+	// +optional
+	Passphrase *PasswordSpec `json:"passphrase,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// PGPKeyPairStatus defines the observed state of PGPKeyPair
+type PGPKeyPairStatus struct {
+	Ready bool `json:"ready"`
+
+	// Fingerprint of the primary key in the backend Secret
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// ExpiresAt is when the key in the backend Secret expires, unset if it doesn't
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// RenewalTime is when the key in the backend Secret will be renewed
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// PGPKeyPairList contains a list of PGPKeyPair
+type PGPKeyPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PGPKeyPair `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// AgeKeyPair is the Schema for the AgeKeyPairs API
+type AgeKeyPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgeKeyPairSpec   `json:"spec,omitempty"`
+	Status AgeKeyPairStatus `json:"status,omitempty"`
+}
+
+// AgeKeyPairSpec defines the desired state of AgeKeyPair
+type AgeKeyPairSpec struct {
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// AgeKeyPairStatus defines the observed state of AgeKeyPair
+type AgeKeyPairStatus struct {
+	Ready bool `json:"ready"`
+
+	// Recipient is the age1... recipient of the live generation
+	// +optional
+	Recipient string `json:"recipient,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// AgeKeyPairList contains a list of AgeKeyPair
+type AgeKeyPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgeKeyPair `json:"items"`
+}
+
+// TOTPAlgorithm is the HMAC algorithm a TOTPSeed's codes are computed with
+type TOTPAlgorithm string
+
+const (
+	TOTPAlgorithmSHA1   TOTPAlgorithm = "SHA1"
+	TOTPAlgorithmSHA256 TOTPAlgorithm = "SHA256"
+	TOTPAlgorithmSHA512 TOTPAlgorithm = "SHA512"
+)
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// TOTPSeed is the Schema for the TOTPSeeds API
+type TOTPSeed struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TOTPSeedSpec   `json:"spec,omitempty"`
+	Status TOTPSeedStatus `json:"status,omitempty"`
+}
+
+// TOTPSeedSpec defines the desired state of TOTPSeed
+type TOTPSeedSpec struct {
+	// Issuer and AccountName label the seed in authenticator apps, e.g. Example and
+	// admin@example.com
+	Issuer      string `json:"issuer"`
+	AccountName string `json:"accountName"`
+
+	// Digits of a code, 6 (the default) or 8
+	// +optional
+	Digits int `json:"digits,omitempty"`
+
+	// Period is how many seconds a code is valid for, 30 by default
+	// +optional
+	Period int `json:"period,omitempty"`
+
+	// Algorithm codes are computed with, SHA1 by default. Not every authenticator app
+	// supports the others.
+	// +optional
+	Algorithm TOTPAlgorithm `json:"algorithm,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// TOTPSeedStatus defines the observed state of TOTPSeed
+type TOTPSeedStatus struct {
+	Ready bool `json:"ready"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// TOTPSeedList contains a list of TOTPSeed
+type TOTPSeedList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TOTPSeed `json:"items"`
+}
+
+// +genclient
+// +k8s:register-gen
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:genclient:method=UpdateStatus,verb=updateStatus,subresource=status, \
+// result=k8s.io/apimachinery/pkg/apis/meta/v1.Status
+// DHParams is the Schema for the DHParams API
+type DHParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DHParamsSpec   `json:"spec,omitempty"`
+	Status DHParamsStatus `json:"status,omitempty"`
+}
+
+// DHParamsSpec defines the desired state of DHParams
+type DHParamsSpec struct {
+	// BitSize of the prime, at least 2048 and 2048 by default. Computing 4096-bit
+	// parameters can take several minutes.
+	// +optional
+	BitSize int `json:"bitSize,omitempty"`
+
+	// Generator of the group, 2 (the default) or 5
+	// +optional
+	Generator int `json:"generator,omitempty"`
+
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+}
+
+// DHParamsStatus defines the observed state of DHParams
+type DHParamsStatus struct {
+	Ready bool `json:"ready"`
+
+	// Computing is set while new parameters are being computed
+	// +optional
+	Computing bool `json:"computing,omitempty"`
+
+	// +optional
+	RotationStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// DHParamsList contains a list of DHParams
+type DHParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DHParams `json:"items"`
+}
@@ -0,0 +1,34 @@
+package v1alpha1
+
+import "testing"
+
+func TestAllowlistFields(t *testing.T) {
+	if len(AllowlistFields) != len(G8sTypes) {
+		t.Errorf("AllowlistFields has %d entries, G8sTypes %d", len(AllowlistFields), len(G8sTypes))
+	}
+	for _, g := range G8sTypes {
+		list, ok := AllowlistFields[g]
+		if !ok {
+			t.Errorf("no AllowlistFields entry for %s", g)
+			continue
+		}
+		if list.Targets == nil || list.Field == "" || list.Prefix == "" {
+			t.Errorf("incomplete AllowlistFields entry for %s: %+v", g, list)
+		}
+	}
+
+	spec := &AllowlistSpec{AgeRecipients: []G8sTargets{{Name: "backups"}}}
+	list := AllowlistFields["AgeRecipients"]
+	if got := list.Targets(spec); len(got) != 1 || list.SecretName(got[0].Name) != "agekeypair-backups-recipient" {
+		t.Errorf("unexpected AgeRecipients targets %v", got)
+	}
+
+	// signers get the backend Secret with the private key, verifiers only the JWKS
+	spec = &AllowlistSpec{JWTSigningKeys: []G8sTargets{{Name: "auth"}}, JWTSigners: []G8sTargets{{Name: "auth"}}}
+	for gt, want := range map[string]string{"JWTSigningKeys": "jwtsigningkey-auth-jwks", "JWTSigners": "jwtsigningkey-auth"} {
+		list := AllowlistFields[gt]
+		if got := list.Targets(spec); len(got) != 1 || list.SecretName(got[0].Name) != want {
+			t.Errorf("unexpected %s targets %v", gt, got)
+		}
+	}
+}
@@ -57,14 +57,38 @@ func init() {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&APIToken{},
+		&APITokenList{},
+		&AgeKeyPair{},
+		&AgeKeyPairList{},
 		&Allowlist{},
 		&AllowlistList{},
+		&Certificate{},
+		&CertificateAuthority{},
+		&CertificateAuthorityList{},
+		&CertificateList{},
+		&DHParams{},
+		&DHParamsList{},
+		&JWTSigningKey{},
+		&JWTSigningKeyList{},
 		&Login{},
 		&LoginList{},
+		&PGPKeyPair{},
+		&PGPKeyPairList{},
+		&RandomSecret{},
+		&RandomSecretList{},
+		&RegistryCredential{},
+		&RegistryCredentialList{},
+		&SSHCertificateAuthority{},
+		&SSHCertificateAuthorityList{},
 		&SSHKeyPair{},
 		&SSHKeyPairList{},
 		&SelfSignedTLSBundle{},
 		&SelfSignedTLSBundleList{},
+		&TOTPSeed{},
+		&TOTPSeedList{},
+		&WireGuardKeyPair{},
+		&WireGuardKeyPairList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
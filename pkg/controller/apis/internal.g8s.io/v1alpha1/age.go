@@ -0,0 +1,21 @@
+package v1alpha1
+
+import (
+	"filippo.io/age"
+)
+
+// newAgeKey generates an X25519 identity the way age-keygen does and returns it
+// along with its recipient, AGE-SECRET-KEY-1... and age1... respectively
+func newAgeKey() (string, string, error) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", err
+	}
+	return identity.String(), identity.Recipient().String(), nil
+}
+
+// ageKeysFile renders an identity as a keys.txt in the format age-keygen writes,
+// which is what age -i and SOPS_AGE_KEY_FILE read
+func ageKeysFile(identity, recipient string) string {
+	return "# public key: " + recipient + "\n" + identity + "\n"
+}
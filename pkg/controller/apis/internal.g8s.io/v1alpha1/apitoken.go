@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+const (
+	// defaultAPITokenLength is the number of random characters of a token, which
+	// like GitHub's gives about 178 bits of entropy
+	defaultAPITokenLength = 30
+
+	// minAPITokenLength keeps tokens from being guessable
+	minAPITokenLength = 20
+
+	// defaultAPITokenGracePeriod is how long the previous token stays valid after a
+	// rotation unless spec.gracePeriod says otherwise
+	defaultAPITokenGracePeriod = time.Hour * 24
+
+	// apiTokenChecksumLength is the number of base62 characters the CRC32 checksum is
+	// encoded in, enough for any uint32
+	apiTokenChecksumLength = 6
+)
+
+// base62Alphabet is the character set of the random part and the checksum of tokens
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// apiTokenPrefix is what prefixes are limited to, so that the _ separator stays
+// unambiguous and scanners can match the prefix literally
+var apiTokenPrefix = regexp.MustCompile(`^[a-z][a-z0-9]{1,15}$`)
+
+// newAPIToken generates a token of length random characters with the given prefix
+// and the checksum of the random part
+func newAPIToken(prefix string, length int) (string, error) {
+	settings := password.Settings{
+		Length:       length,
+		CharacterSet: base62Alphabet,
+	}
+	random, err := settings.Generate()
+	if err != nil {
+		return "", err
+	}
+	return prefix + "_" + random + apiTokenChecksum(random), nil
+}
+
+// apiTokenChecksum returns the CRC32 of the random part of a token, base62 encoded
+// and zero padded
+func apiTokenChecksum(random string) string {
+	encoded := []byte(strings.Repeat("0", apiTokenChecksumLength))
+	n := crc32.ChecksumIEEE([]byte(random))
+	for i := len(encoded) - 1; n > 0; i-- {
+		encoded[i] = base62Alphabet[n%62]
+		n /= 62
+	}
+	return string(encoded)
+}
+
+// ValidAPITokenChecksum reports whether the checksum at the end of token matches its
+// random part, which tells a token apart from something that only looks like one
+func ValidAPITokenChecksum(token string) bool {
+	i := strings.LastIndex(token, "_")
+	if i < 0 || len(token)-i-1 <= apiTokenChecksumLength {
+		return false
+	}
+	body := token[i+1:]
+	random, checksum := body[:len(body)-apiTokenChecksumLength], body[len(body)-apiTokenChecksumLength:]
+	return apiTokenChecksum(random) == checksum
+}
+
+// tokenDigest returns the hex encoded SHA-256 of a token, which services can compare
+// incoming tokens against without having to know the token itself
+func tokenDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// validateAPIToken checks the parts of an APITokenSpec the CRD schema can't
+func validateAPIToken(spec v1alpha1.APITokenSpec) error {
+	if !apiTokenPrefix.MatchString(spec.Prefix) {
+		return fmt.Errorf("prefix must be 2 to 16 lowercase letters and digits starting with a letter, got %q", spec.Prefix)
+	}
+	if spec.Length != 0 && spec.Length < minAPITokenLength {
+		return fmt.Errorf("length must be at least %d, got %d", minAPITokenLength, spec.Length)
+	}
+	return nil
+}
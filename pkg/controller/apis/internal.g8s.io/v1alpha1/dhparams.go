@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+const (
+	// defaultDHBitSize is the size of the prime unless spec.bitSize says otherwise
+	defaultDHBitSize = 2048
+	// defaultDHGenerator is the generator unless spec.generator says otherwise
+	defaultDHGenerator = 2
+)
+
+// dhSmallPrimes are the odd primes candidates are sieved with before the far more
+// expensive primality tests
+var dhSmallPrimes = func() []int64 {
+	var primes []int64
+	for n := int64(3); n < 2048; n += 2 {
+		if big.NewInt(n).ProbablyPrime(0) {
+			primes = append(primes, n)
+		}
+	}
+	return primes
+}()
+
+// dhSearchWindow is how far past a random start candidates are searched for before
+// starting over from another one
+const dhSearchWindow = 1 << 24
+
+// dhParameter is the PKCS #3 DHParameter that dhparam.pem holds, as written by
+// openssl dhparam
+type dhParameter struct {
+	Prime *big.Int
+	Base  *big.Int
+}
+
+// validateDHParams checks that spec describes parameters that can be generated
+func validateDHParams(spec v1alpha1.DHParamsSpec) error {
+	if spec.BitSize != 0 && spec.BitSize < 2048 {
+		return fmt.Errorf("bitSize must be at least 2048, got %d", spec.BitSize)
+	}
+	if spec.BitSize > 8192 {
+		return fmt.Errorf("bitSize must be at most 8192, got %d", spec.BitSize)
+	}
+	switch spec.Generator {
+	case 0, 2, 5:
+		return nil
+	default:
+		return fmt.Errorf("generator must be 2 or 5, got %d", spec.Generator)
+	}
+}
+
+// dhParamsSize returns the bit size and generator spec asks for, with their defaults
+func dhParamsSize(spec v1alpha1.DHParamsSpec) (int, int) {
+	bits, generator := spec.BitSize, spec.Generator
+	if bits == 0 {
+		bits = defaultDHBitSize
+	}
+	if generator == 0 {
+		generator = defaultDHGenerator
+	}
+	return bits, generator
+}
+
+// newDHParams generates a safe prime p = 2q + 1 of bits bits and returns it with
+// generator as a PEM encoded dhparam.pem. Like openssl dhparam, p is picked so that
+// generator generates the subgroup of order q, p ≡ 23 mod 24 for 2 and p ≡ 59 mod 60
+// for 5. This takes minutes for large bit sizes, so it gives up once ctx is done.
+func newDHParams(ctx context.Context, bits, generator int) (string, error) {
+	var add, rem int64
+	switch generator {
+	case 2:
+		add, rem = 24, 23
+	case 5:
+		add, rem = 60, 59
+	default:
+		return "", fmt.Errorf("unsupported generator %d", generator)
+	}
+
+	bigAdd, bigRem := big.NewInt(add), big.NewInt(rem)
+	p, q, m := new(big.Int), new(big.Int), new(big.Int)
+	residues := make([]int64, len(dhSmallPrimes))
+	b := make([]byte, (bits+7)/8)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		// set the top two bits so that p has exactly bits bits, then move p to the
+		// residue class the generator needs
+		b[0] &= 0xff >> uint(len(b)*8-bits)
+		start := new(big.Int).SetBytes(b)
+		start.SetBit(start, bits-1, 1)
+		start.SetBit(start, bits-2, 1)
+		start.Sub(start, m.Mod(start, bigAdd))
+		start.Add(start, bigRem)
+		for i, sp := range dhSmallPrimes {
+			residues[i] = m.Mod(start, big.NewInt(sp)).Int64()
+		}
+
+		// walk the residue class up from start, sieving with the residues of start
+		// instead of dividing every candidate
+	search:
+		for delta := int64(0); delta < dhSearchWindow; delta += add {
+			for i, sp := range dhSmallPrimes {
+				// sp divides p if p ≡ 0 mod sp, and q = (p-1)/2 if p ≡ 1 mod sp
+				if r := (residues[i] + delta) % sp; r == 0 || r == 1 {
+					continue search
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+
+			p.Add(start, big.NewInt(delta))
+			if p.BitLen() != bits {
+				break
+			}
+			q.Rsh(p, 1)
+			if q.ProbablyPrime(0) && p.ProbablyPrime(0) && q.ProbablyPrime(20) && p.ProbablyPrime(20) {
+				der, err := asn1.Marshal(dhParameter{Prime: p, Base: big.NewInt(int64(generator))})
+				if err != nil {
+					return "", err
+				}
+				return string(pem.EncodeToMemory(&pem.Block{Type: "DH PARAMETERS", Bytes: der})), nil
+			}
+		}
+	}
+}
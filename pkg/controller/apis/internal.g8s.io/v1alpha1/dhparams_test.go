@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestNewDHParams(t *testing.T) {
+	// far below what validateDHParams allows, to keep the search short
+	const bits = 256
+	tests := []struct {
+		generator int
+		mod, rem  int64
+	}{
+		{2, 24, 23},
+		{5, 60, 59},
+	}
+
+	for _, tt := range tests {
+		dhparam, err := newDHParams(context.Background(), bits, tt.generator)
+		if err != nil {
+			t.Fatalf("newDHParams(%d, %d) error = %v", bits, tt.generator, err)
+		}
+
+		block, rest := pem.Decode([]byte(dhparam))
+		if block == nil || block.Type != "DH PARAMETERS" || len(rest) != 0 {
+			t.Fatalf("dhparam.pem %q isn't a single DH PARAMETERS block", dhparam)
+		}
+		// DHParameter ::= SEQUENCE { prime INTEGER, base INTEGER, privateValueLength INTEGER OPTIONAL }
+		var params struct {
+			P, G *big.Int
+		}
+		if rest, err := asn1.Unmarshal(block.Bytes, &params); err != nil || len(rest) != 0 {
+			t.Fatalf("cannot parse PKCS #3 DHParameter: %v", err)
+		}
+
+		p := params.P
+		q := new(big.Int).Rsh(p, 1)
+		if p.BitLen() != bits {
+			t.Errorf("generator %d: p has %d bits, want %d", tt.generator, p.BitLen(), bits)
+		}
+		if params.G.Int64() != int64(tt.generator) {
+			t.Errorf("generator %d: g = %v", tt.generator, params.G)
+		}
+		if !p.ProbablyPrime(32) || !q.ProbablyPrime(32) {
+			t.Errorf("generator %d: p = %v isn't a safe prime", tt.generator, p)
+		}
+		if r := new(big.Int).Mod(p, big.NewInt(tt.mod)).Int64(); r != tt.rem {
+			t.Errorf("generator %d: p mod %d = %d, want %d", tt.generator, tt.mod, r, tt.rem)
+		}
+		// g generates the subgroup of order q, so g^q = 1 mod p
+		if new(big.Int).Exp(params.G, q, p).Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("generator %d: g doesn't generate the subgroup of order q", tt.generator)
+		}
+	}
+}
+
+func TestNewDHParamsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := newDHParams(ctx, 4096, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("newDHParams() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -1,20 +1,16 @@
 package v1alpha1
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"context"
+	"crypto"
 	"crypto/x509"
-	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
-	"math"
-	"math/big"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/keygen"
 	"github.com/crossplane/crossplane-runtime/pkg/password"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,8 +27,10 @@ type Meta struct {
 // generators for gates
 type G8s interface {
 	GetMeta() Meta
-	Generate() map[string]string
-	Rotate() map[string]string
+	SetHistory(data map[string][]byte)
+	Generate() (map[string]string, error)
+	Rotate() (map[string]string, error)
+	BackendContent(history map[string]string, gen int) map[string]string
 }
 
 // use to get a standard ObjectMeta when creating objects
@@ -51,13 +49,27 @@ func NewG8sObjectMeta(g8s G8s, name string) metav1.ObjectMeta {
 	}
 }
 
+// annotated is implemented by g8s types whose backend Secret carries extra annotations
+type annotated interface {
+	BackendAnnotations() map[string]string
+}
+
 func NewBackendSecret(g8s G8s, content map[string]string, secretType corev1.SecretType) *corev1.Secret {
 	meta := g8s.GetMeta()
 	name := strings.ToLower(meta.Kind + "-" + meta.Name)
+	objectMeta := NewG8sObjectMeta(g8s, name)
+	if a, ok := g8s.(annotated); ok {
+		for k, v := range a.BackendAnnotations() {
+			objectMeta.Annotations[k] = v
+		}
+	}
+
+	// Data rather than StringData, which is sent as JSON strings and so can't carry
+	// binary values like the QR code of a TOTPSeed
 	return &corev1.Secret{
-		ObjectMeta: NewG8sObjectMeta(g8s, name),
+		ObjectMeta: objectMeta,
 		Immutable:  boolPtr(true),
-		StringData: content,
+		Data:       byteData(content),
 		Type:       secretType,
 	}
 }
@@ -73,13 +85,30 @@ func NewHistorySecret(g8s G8s, content map[string]string) *corev1.Secret {
 	}
 }
 
+// StringData converts the Data of an existing Secret back into the form used for
+// StringData when creating one
+func StringData(data map[string][]byte) map[string]string {
+	content := make(map[string]string, len(data))
+	for k, v := range data {
+		content[k] = string(v)
+	}
+	return content
+}
+
+// byteData converts content into the form used for the Data of a Secret
+func byteData(content map[string]string) map[string][]byte {
+	data := make(map[string][]byte, len(content))
+	for k, v := range content {
+		data[k] = []byte(v)
+	}
+	return data
+}
+
 // Secret.Immutable requires a *bool, helper func to return that
 func boolPtr(b bool) *bool {
 	return &b
 }
 
-type history []string
-
 type Login struct {
 	v1alpha1.Login
 	history
@@ -92,7 +121,7 @@ func NewLogin(l *v1alpha1.Login) *Login {
 	}
 	return &Login{
 		*l,
-		[]string{},
+		history{},
 	}
 }
 
@@ -103,36 +132,116 @@ func (l Login) GetMeta() Meta {
 	}
 }
 
-// errors can be ignored because if there's a problem it will be handled in the controller (processNextWorkItem will requeue it)
-func (l Login) Generate() map[string]string {
-	settings := password.Settings{
-		Length:       int(l.Spec.Password.Length),
-		CharacterSet: l.Spec.Password.CharacterSet,
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (l *Login) SetHistory(data map[string][]byte) {
+	l.history = newHistory(data, append([]string{"password"}, PasswordHashFields...)...)
+}
+
+func (l Login) Generate() (map[string]string, error) {
+	content := map[string]string{
+		"password": generatePassword(l.Spec.Password),
+	}
+	// hashes are salted, so they're generated along with the password and kept in the
+	// history to be restored with it
+	for _, field := range passwordHashFieldsOf(l.Spec.Password) {
+		hash, err := hashPassword(field, content["password"])
+		if err != nil {
+			return nil, err
+		}
+		content[field] = hash
 	}
+	return content, nil
+}
 
-	pwstr, _ := settings.Generate()
+func (l Login) Rotate() (map[string]string, error) {
+	content, err := l.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return l.history.rotate(content), nil
+}
 
-	return map[string]string{
-		"password": pwstr,
+// BackendContent returns the password of generation gen with the username and the
+// hashes spec.password.hashes asks for
+func (l Login) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "password")
+	if content != nil {
+		content["username"] = l.Spec.Username
+		addPasswordHashes(l.Spec.Password, l.Spec.Username, history, gen, content)
+	}
+	return content
+}
+
+// CompleteHashes returns a copy of history with the hashes spec.password.hashes asks
+// for added to generation gen, and whether any were missing from it
+func (l Login) CompleteHashes(history map[string]string, gen int) (map[string]string, bool, error) {
+	return completePasswordHashes(l.Spec.Password, history, gen)
+}
+
+// HashesDrifted reports whether the password hashes in the data of the backend Secret
+// differ from the ones spec.password.hashes asks for
+func (l Login) HashesDrifted(data map[string][]byte) bool {
+	return passwordHashesDrifted(l.Spec.Password, data)
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (l Login) Validate() error {
+	return validatePasswordHashes(l.Spec.Password)
+}
+
+// NewLoginHashesSecret returns the Secret that publishes the username and password
+// hashes of a Login's live generation, content being its backend Secret's. Unlike the
+// backend Secret it holds no password, so it is the one that Allowlists propagate to
+// servers that only check passwords.
+func NewLoginHashesSecret(l *Login, content map[string]string) *corev1.Secret {
+	meta := l.GetMeta()
+	name := strings.ToLower(meta.Kind + "-" + meta.Name + "-hashes")
+	data := map[string]string{"username": content["username"]}
+	for _, k := range PasswordHashKeys {
+		if v, ok := content[k]; ok {
+			data[k] = v
+		}
+	}
+	return &corev1.Secret{
+		ObjectMeta: NewG8sObjectMeta(l, name),
+		Immutable:  boolPtr(true),
+		StringData: data,
+		Type:       "g8s.io/login-hashes",
 	}
 }
 
-func (l Login) Rotate() map[string]string {
-	newPassword := l.Generate()
-	newHistory := append([]string{newPassword["password"]}, l.history...)
-	newData := make(map[string]string)
+// defaultPasswordLength is the length of generated passwords if the PasswordSpec
+// doesn't set one
+const defaultPasswordLength = 32
+
+// defaultPasswordCharacterSet is used for generated passwords if the PasswordSpec
+// doesn't set a character set
+const defaultPasswordCharacterSet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
-	for i, pw := range newHistory {
-		hist := "password-" + strconv.Itoa(i)
-		newData[hist] = pw
+// generatePassword generates a password as described by spec, falling back to
+// defaults for an empty length or character set. Every g8s type that generates a
+// password or passphrase uses it, so they all follow the same PasswordSpec.
+func generatePassword(spec *v1alpha1.PasswordSpec) string {
+	settings := password.Settings{
+		Length:       defaultPasswordLength,
+		CharacterSet: defaultPasswordCharacterSet,
+	}
+	if spec != nil && spec.Length != 0 {
+		settings.Length = int(spec.Length)
+	}
+	if spec != nil && spec.CharacterSet != "" {
+		settings.CharacterSet = spec.CharacterSet
 	}
 
-	return newData
+	pw, _ := settings.Generate()
+	return pw
 }
 
 type SSHKeyPair struct {
 	v1alpha1.SSHKeyPair
 	history
+	caKey string
 }
 
 func NewSSHKeyPair(ssh *v1alpha1.SSHKeyPair) *SSHKeyPair {
@@ -141,8 +250,8 @@ func NewSSHKeyPair(ssh *v1alpha1.SSHKeyPair) *SSHKeyPair {
 		APIVersion: "api.g8s.io/v1alpha1",
 	}
 	return &SSHKeyPair{
-		*ssh,
-		[]string{},
+		SSHKeyPair: *ssh,
+		history:    history{},
 	}
 }
 
@@ -153,46 +262,242 @@ func (ssh SSHKeyPair) GetMeta() Meta {
 	}
 }
 
-// errors can be ignored because if there's a problem it will be handled in the controller (processNextWorkItem will requeue it)
-func (ssh SSHKeyPair) Generate() map[string]string {
-	keyType := keygen.KeyType(ssh.Spec.KeyType)
-	opts := []keygen.Option{(keygen.WithKeyType(keyType)), keygen.WithBitSize(ssh.Spec.BitSize)}
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (ssh *SSHKeyPair) SetHistory(data map[string][]byte) {
+	ssh.history = newHistory(data, "ssh.pub", "ssh.key", "ssh.passphrase", "ssh-cert.pub")
+}
 
-	keyPair, _ := keygen.New("", opts...)
+// SetCertificateAuthority loads the CA key that signs ssh-cert.pub from the data of
+// the SSHCertificateAuthority's backend Secret, it has to be set before Generate or
+// Renew is called if spec.certificate is set
+func (ssh *SSHKeyPair) SetCertificateAuthority(data map[string][]byte) error {
+	if _, err := parseSSHSigner(string(data["ca.key"])); err != nil {
+		return fmt.Errorf("cannot parse key of SSHCertificateAuthority '%s': %s", ssh.Spec.Certificate.CertificateAuthorityRef, err.Error())
+	}
+	ssh.caKey = string(data["ca.key"])
+	return nil
+}
 
-	pub := keyPair.RawAuthorizedKey()
-	pubStr := string(pub[:])
+func (ssh SSHKeyPair) Generate() (map[string]string, error) {
+	passphrase := ""
+	if ssh.Spec.Passphrase != nil {
+		passphrase = generatePassword(ssh.Spec.Passphrase)
+	}
 
-	key := keyPair.RawPrivateKey()
-	keyStr := string(key[:])
+	keyPair, err := newSSHKey(ssh.Spec.KeyType, ssh.Spec.Curve, ssh.Spec.BitSize, passphrase)
+	if err != nil {
+		return nil, err
+	}
 
-	return map[string]string{
-		"ssh.pub": pubStr,
-		"ssh.key": keyStr,
+	content := map[string]string{
+		"ssh.pub": authorizedKey(keyPair.PublicKey(), ssh.Spec.Comment),
+		"ssh.key": string(keyPair.RawProtectedPrivateKey()),
+	}
+	if passphrase != "" {
+		content["ssh.passphrase"] = passphrase
+	}
+	cert, err := ssh.certificate(content["ssh.pub"])
+	if err != nil {
+		return nil, err
+	}
+	if cert != "" {
+		content["ssh-cert.pub"] = cert
+	}
+	return content, nil
+}
+
+func (ssh SSHKeyPair) Rotate() (map[string]string, error) {
+	content, err := ssh.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return ssh.history.rotate(content), nil
+}
+
+// Renew returns the content of a history Secret with a new generation that keeps the
+// key of the newest one and only reissues ssh-cert.pub
+func (ssh SSHKeyPair) Renew() (map[string]string, error) {
+	if len(ssh.history) == 0 {
+		return ssh.Rotate()
+	}
+
+	newest := make(map[string]string)
+	for f, v := range ssh.history[0] {
+		if f != metaField && f != "ssh-cert.pub" {
+			newest[f] = v
+		}
+	}
+	cert, err := ssh.certificate(newest["ssh.pub"])
+	if err != nil {
+		return nil, err
 	}
+	if cert != "" {
+		newest["ssh-cert.pub"] = cert
+	}
+	return ssh.history.rotate(newest), nil
 }
 
-func (ssh SSHKeyPair) Rotate() map[string]string {
-	newSSHKeyPair := ssh.Generate()
-	newHistory := append([]string{newSSHKeyPair["ssh.pub"]}, newSSHKeyPair["ssh.key"])
-	newHistory = append(newHistory, ssh.history...)
-	newData := make(map[string]string)
+// certificate signs pub as described by spec.certificate, or returns an empty string
+// if there's no certificate to issue
+func (ssh SSHKeyPair) certificate(pub string) (string, error) {
+	if ssh.Spec.Certificate == nil || ssh.caKey == "" {
+		return "", nil
+	}
+
+	ca, err := parseSSHSigner(ssh.caKey)
+	if err != nil {
+		return "", sshCertificateError{err}
+	}
+	cert, err := signSSHCertificate(ssh.Spec.Certificate, ssh.Namespace+"/"+ssh.Name, pub, ssh.CertificateDuration(), ca, time.Now().UTC())
+	if err != nil {
+		return "", sshCertificateError{err}
+	}
+	return cert, nil
+}
 
-	count := 0
-	hist := ""
-	for i, ssh := range newHistory {
-		mod := i % 2
+// BackendContent returns ssh.pub, ssh.key and the fingerprint of generation gen,
+// along with ssh.passphrase if the key is encrypted and ssh-cert.pub if it's signed
+func (ssh SSHKeyPair) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "ssh.pub", "ssh.key")
+	if content == nil {
+		return nil
+	}
 
-		if mod == 0 {
-			hist = "ssh.pub-" + strconv.Itoa(count)
-		} else if mod == 1 {
-			hist = "ssh.key-" + strconv.Itoa(count)
-			count++
+	for _, f := range []string{"ssh.passphrase", "ssh-cert.pub"} {
+		if optional := generation(history, gen, f); optional != nil {
+			content[f] = optional[f]
 		}
-		newData[hist] = ssh
+	}
+	content["ssh.fingerprint"] = SSHFingerprint(content["ssh.pub"])
+	return content
+}
+
+// Validate checks that the spec describes a key that can be generated
+func (ssh SSHKeyPair) Validate() error {
+	if err := validateSSHKey(ssh.Spec.KeyType, ssh.Spec.Curve, ssh.Spec.BitSize); err != nil {
+		return err
+	}
+	if ssh.Spec.Certificate != nil {
+		return validateSSHCertificate(ssh.Spec.Certificate)
+	}
+	return nil
+}
+
+// CertificateDuration returns how long issued certificates are valid for
+func (ssh SSHKeyPair) CertificateDuration() time.Duration {
+	if ssh.Spec.Certificate == nil {
+		return defaultSSHCertDuration
+	}
+	return durationOrDefault(ssh.Spec.Certificate.Duration, defaultSSHCertDuration)
+}
+
+// CertificateRenewBefore returns how long before expiry issued certificates are renewed
+func (ssh SSHKeyPair) CertificateRenewBefore() time.Duration {
+	if ssh.Spec.Certificate == nil {
+		return renewBefore(nil, ssh.CertificateDuration())
+	}
+	return renewBefore(ssh.Spec.Certificate.RenewBefore, ssh.CertificateDuration())
+}
+
+type SSHCertificateAuthority struct {
+	v1alpha1.SSHCertificateAuthority
+	history
+}
+
+func NewSSHCertificateAuthority(ca *v1alpha1.SSHCertificateAuthority) *SSHCertificateAuthority {
+	ca.TypeMeta = metav1.TypeMeta{
+		Kind:       "SSHCertificateAuthority",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &SSHCertificateAuthority{
+		*ca,
+		history{},
+	}
+}
+
+func (ca SSHCertificateAuthority) GetMeta() Meta {
+	return Meta{
+		ca.TypeMeta,
+		ca.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (ca *SSHCertificateAuthority) SetHistory(data map[string][]byte) {
+	ca.history = newHistory(data, "ca.pub", "ca.key")
+}
+
+func (ca SSHCertificateAuthority) Generate() (map[string]string, error) {
+	keyPair, err := newSSHKey(ca.keyType(), ca.Spec.Curve, ca.Spec.BitSize, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"ca.pub": authorizedKey(keyPair.PublicKey(), ""),
+		"ca.key": string(keyPair.RawPrivateKey()),
+	}, nil
+}
+
+func (ca SSHCertificateAuthority) Rotate() (map[string]string, error) {
+	content, err := ca.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return ca.history.rotate(content), nil
+}
+
+func (ca SSHCertificateAuthority) BackendContent(history map[string]string, gen int) map[string]string {
+	return generation(history, gen, "ca.pub", "ca.key")
+}
+
+// Validate checks that the spec describes a key that can be generated
+func (ca SSHCertificateAuthority) Validate() error {
+	return validateSSHKey(ca.keyType(), ca.Spec.Curve, ca.Spec.BitSize)
+}
+
+// keyType returns the type of the CA key, ed25519 if the spec doesn't set one
+func (ca SSHCertificateAuthority) keyType() v1alpha1.SSHKeyPairType {
+	if ca.Spec.KeyType == "" {
+		return v1alpha1.Ed25519
+	}
+	return ca.Spec.KeyType
+}
+
+// NewSSHTrustSecret returns the Secret that publishes the CA public keys of every
+// generation of an SSHCertificateAuthority's history. ca.pub can be used as the
+// TrustedUserCAKeys file of sshd, known_hosts holds the same keys as @cert-authority
+// lines for clients. Unlike the backend Secret it holds no key, so it is the one that
+// Allowlists propagate.
+func NewSSHTrustSecret(ca *SSHCertificateAuthority, history map[string]string) *corev1.Secret {
+	meta := ca.GetMeta()
+	name := strings.ToLower(meta.Kind + "-" + meta.Name + "-trust")
+	return &corev1.Secret{
+		ObjectMeta: NewG8sObjectMeta(ca, name),
+		Immutable:  boolPtr(true),
+		StringData: ca.TrustContent(history),
+		Type:       "g8s.io/ssh-trust",
+	}
+}
+
+// TrustContent returns the content of the trust Secret for history
+func (ca SSHCertificateAuthority) TrustContent(history map[string]string) map[string]string {
+	patterns := "*"
+	if len(ca.Spec.HostPatterns) > 0 {
+		patterns = strings.Join(ca.Spec.HostPatterns, ",")
 	}
 
-	return newData
+	var trusted, knownHosts strings.Builder
+	for _, pub := range sshTrustedKeys(history) {
+		trusted.WriteString(pub + "\n")
+		knownHosts.WriteString("@cert-authority " + patterns + " " + pub + "\n")
+	}
+	return map[string]string{
+		"ca.pub":      trusted.String(),
+		"known_hosts": knownHosts.String(),
+	}
 }
 
 type SelfSignedTLSBundle struct {
@@ -207,7 +512,7 @@ func NewSelfSignedTLSBundle(sstls *v1alpha1.SelfSignedTLSBundle) *SelfSignedTLSB
 	}
 	return &SelfSignedTLSBundle{
 		*sstls,
-		[]string{},
+		history{},
 	}
 }
 
@@ -218,98 +523,1172 @@ func (sstls SelfSignedTLSBundle) GetMeta() Meta {
 	}
 }
 
-// errors can be ignored because if there's a problem it will be handled in the controller (processNextWorkItem will requeue it)
-func (sstls SelfSignedTLSBundle) Generate() map[string]string {
-	// create private key and self-signed CA cert for signing client's TLS cert
-	ecdsaCAKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	caSerial, _ := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64-1))
-	caSerial = new(big.Int).Add(caSerial, big.NewInt(1))
-	x509CACert := &x509.Certificate{
-		SerialNumber: caSerial,
-		Subject: pkix.Name{
-			CommonName:   sstls.Spec.AppName,
-			Organization: []string{"g8s"},
-		},
-		DNSNames:              sstls.Spec.SANs,
-		NotBefore:             time.Now().UTC(),
-		NotAfter:              time.Now().Add(time.Hour * 24 * 365).UTC(),
-		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
-		BasicConstraintsValid: true,
-		IsCA:                  true,
-	}
-
-	caCertBytes, _ := x509.CreateCertificate(rand.Reader, x509CACert, x509CACert, &ecdsaCAKey.PublicKey, ecdsaCAKey)
-	x509CACert, _ = x509.ParseCertificate(caCertBytes)
-
-	// use CA cert to sign client's TLS cert
-	ecdsaClientKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	clientSerial := new(big.Int).Add(caSerial, big.NewInt(1))
-	x509ClientCert := &x509.Certificate{
-		SerialNumber: clientSerial,
-		Subject: pkix.Name{
-			CommonName:   sstls.Spec.AppName,
-			Organization: []string{"g8s"},
-		},
-		DNSNames:    sstls.Spec.SANs,
-		NotBefore:   time.Now().UTC(),
-		NotAfter:    time.Now().Add(time.Hour * 24 * 365).UTC(),
-		KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (sstls *SelfSignedTLSBundle) SetHistory(data map[string][]byte) {
+	sstls.history = newHistory(data, "key.pem", "cert.pem", "cacert.pem", "cakey.pem", "signer", KeystorePasswordField)
+}
+
+func (sstls SelfSignedTLSBundle) Generate() (map[string]string, error) {
+	now := time.Now().UTC()
+	spec := sstls.Spec.LeafCertificateSpec
+
+	// reuse the newest CA and the trust bundle it was published in as long as the CA
+	// outlives the new cert, so that renewing the cert doesn't invalidate every trust
+	// bundle the CA was handed out to
+	var caCert *x509.Certificate
+	var caKey crypto.Signer
+	var bundle string
+	if len(sstls.history) > 0 {
+		caCert, caKey = parseCA(sstls.history[0]["cacert.pem"], sstls.history[0]["cakey.pem"])
+		bundle = sstls.history[0]["cacert.pem"]
+	}
+	if caCert == nil || caCert.NotAfter.Before(now.Add(sstls.Duration())) {
+		// create private key and self-signed CA cert for signing client's TLS cert
+		var err error
+		caCert, caKey, err = sstls.newCA(now)
+		if err != nil {
+			return nil, err
+		}
+		bundle = encodeCert(caCert)
+	}
+
+	keyPEM, certPEM, err := issueLeaf(spec, sstls.Duration(), caCert, caKey, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// the CA key only ever goes into the history, never into the backend Secret
+	content := map[string]string{
+		"key.pem":    keyPEM,
+		"cert.pem":   certPEM,
+		"cacert.pem": bundle,
+		"cakey.pem":  encodePKCS8(caKey),
+		"signer":     certFingerprint(caCert),
+	}
+	if sstls.Spec.Keystores != nil {
+		content[KeystorePasswordField] = generatePassword(sstls.Spec.Keystores.Password)
+	}
+	return content, nil
+}
+
+func (sstls SelfSignedTLSBundle) Rotate() (map[string]string, error) {
+	content, err := sstls.Generate()
+	if err != nil {
+		return nil, err
 	}
+	return sstls.history.rotate(content), nil
+}
+
+// newCA creates a CA for the bundle, valid for caDurationFactor times its certs
+func (sstls SelfSignedTLSBundle) newCA(now time.Time) (*x509.Certificate, crypto.Signer, error) {
+	spec := sstls.Spec.LeafCertificateSpec
+	return newCA(leafSubject(spec), spec.SANs, spec.KeyAlgorithm, now, now.Add(sstls.Duration()*caDurationFactor))
+}
+
+// CAOverlap returns how long each phase of a CA rotation lasts
+func (sstls SelfSignedTLSBundle) CAOverlap() time.Duration {
+	if sstls.Spec.CARotation == nil {
+		return defaultCAOverlap
+	}
+	return durationOrDefault(sstls.Spec.CARotation.Overlap, defaultCAOverlap)
+}
+
+// CARotationDue reports whether the newest CA expires before a cert issued now, plus
+// both phases of a CA rotation, would. Rotating it then leaves clients time to pick
+// up the new CA before any cert is issued by it.
+func (sstls SelfSignedTLSBundle) CARotationDue(now time.Time) bool {
+	if len(sstls.history) == 0 {
+		return false
+	}
+	caCert, _ := parseCA(sstls.history[0]["cacert.pem"], sstls.history[0]["cakey.pem"])
+	return caCert != nil && caCert.NotAfter.Before(now.Add(sstls.Duration()+2*sstls.CAOverlap()))
+}
 
-	clientCertBytes, err := x509.CreateCertificate(rand.Reader, x509ClientCert, x509CACert, &ecdsaClientKey.PublicKey, ecdsaCAKey)
+// PublishCA starts a CA rotation by creating a new CA and publishing it ahead of the
+// current one in cacert.pem, the cert itself is left as it is. Rotate then issues the
+// cert by the new CA, and RetireCA drops the old CA from cacert.pem.
+func (sstls SelfSignedTLSBundle) PublishCA() (map[string]string, error) {
+	caCert, caKey, err := sstls.newCA(time.Now().UTC())
 	if err != nil {
-		fmt.Println("clientCertBytes: ", err, string(clientCertBytes))
+		return nil, err
+	}
+	g := sstls.current()
+	g["cacert.pem"] = encodeCert(caCert) + firstCert(g["cacert.pem"])
+	g["cakey.pem"] = encodePKCS8(caKey)
+	return sstls.history.rotate(g), nil
+}
+
+// RetireCA completes a CA rotation by dropping every CA but the newest from cacert.pem
+func (sstls SelfSignedTLSBundle) RetireCA() map[string]string {
+	g := sstls.current()
+	g["cacert.pem"] = firstCert(g["cacert.pem"])
+	return sstls.history.rotate(g)
+}
+
+// current returns a copy of the fields of the newest generation, without its metadata
+func (sstls SelfSignedTLSBundle) current() map[string]string {
+	g := make(map[string]string)
+	for _, f := range []string{"key.pem", "cert.pem", "cacert.pem", "cakey.pem", "signer", KeystorePasswordField} {
+		if v, ok := sstls.history[0][f]; ok {
+			g[f] = v
+		}
+	}
+	return g
+}
+
+func (sstls SelfSignedTLSBundle) BackendContent(history map[string]string, gen int) map[string]string {
+	return leafBackendContent(sstls.Spec.LeafCertificateSpec, leafBackendFields(history, gen))
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (sstls SelfSignedTLSBundle) Validate() error {
+	if err := validateSignerName(sstls.Spec.SignerName); err != nil {
+		return err
 	}
+	return validateLeaf(sstls.Spec.LeafCertificateSpec)
+}
+
+// Duration returns how long issued certs are valid for
+func (sstls SelfSignedTLSBundle) Duration() time.Duration {
+	return durationOrDefault(sstls.Spec.Duration, defaultCertDuration)
+}
+
+// RenewBefore returns how long before expiry issued certs are renewed
+func (sstls SelfSignedTLSBundle) RenewBefore() time.Duration {
+	return renewBefore(sstls.Spec.RenewBefore, sstls.Duration())
+}
+
+// BackendKey returns the key a field of the history is stored under in the backend
+// Secret, which depends on the output format
+func (sstls SelfSignedTLSBundle) BackendKey(field string) string {
+	return leafBackendKey(sstls.Spec.LeafCertificateSpec, field)
+}
+
+// KeystoresDrifted reports whether the keystores in the data of the backend Secret
+// differ from the ones the spec asks for
+func (sstls SelfSignedTLSBundle) KeystoresDrifted(data map[string][]byte) bool {
+	return keystoresDrifted(sstls.Spec.LeafCertificateSpec, data)
+}
+
+// SecretType returns the type of the backend Secret, which depends on the output format
+func (sstls SelfSignedTLSBundle) SecretType() corev1.SecretType {
+	return leafSecretType(sstls.Spec.LeafCertificateSpec, "g8s.io/self-signed-tls-bundle")
+}
+
+// BackendAnnotations returns cert-manager's Secret annotations if they were asked for
+func (sstls SelfSignedTLSBundle) BackendAnnotations() map[string]string {
+	return certManagerAnnotations(sstls.Spec.LeafCertificateSpec, sstls.Name, sstls.Name, sstls.Kind)
+}
+
+type CertificateAuthority struct {
+	v1alpha1.CertificateAuthority
+	history
+}
 
-	// encode these things to DER strings
-	clientKeyBytes, _ := x509.MarshalECPrivateKey(ecdsaClientKey)
-	clientKeyBlock := &pem.Block{
-		Type:  "EC PRIVATE KEY",
-		Bytes: clientKeyBytes,
+func NewCertificateAuthority(ca *v1alpha1.CertificateAuthority) *CertificateAuthority {
+	ca.TypeMeta = metav1.TypeMeta{
+		Kind:       "CertificateAuthority",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &CertificateAuthority{
+		*ca,
+		history{},
 	}
-	keyPEM := string(pem.EncodeToMemory(clientKeyBlock))
+}
 
-	clientCertBlock := &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: clientCertBytes,
+func (ca CertificateAuthority) GetMeta() Meta {
+	return Meta{
+		ca.TypeMeta,
+		ca.ObjectMeta,
 	}
-	certPEM := string(pem.EncodeToMemory(clientCertBlock))
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (ca *CertificateAuthority) SetHistory(data map[string][]byte) {
+	ca.history = newHistory(data, "cacert.pem", "cakey.pem")
+}
 
-	caCertBlock := &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: caCertBytes,
+func (ca CertificateAuthority) Generate() (map[string]string, error) {
+	now := time.Now().UTC()
+	cert, key, err := newCA(newSubject(ca.Spec.CommonName, ca.Spec.Subject), nil, ca.Spec.KeyAlgorithm, now, now.Add(ca.Duration()))
+	if err != nil {
+		return nil, err
 	}
-	caCertPEM := string(pem.EncodeToMemory(caCertBlock))
 
 	return map[string]string{
+		"cacert.pem": encodeCert(cert),
+		"cakey.pem":  encodePKCS8(key),
+	}, nil
+}
+
+func (ca CertificateAuthority) Rotate() (map[string]string, error) {
+	content, err := ca.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return ca.history.rotate(content), nil
+}
+
+func (ca CertificateAuthority) BackendContent(history map[string]string, gen int) map[string]string {
+	return generation(history, gen, "cacert.pem", "cakey.pem")
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (ca CertificateAuthority) Validate() error {
+	if ca.Spec.CommonName == "" {
+		return fmt.Errorf("commonName is required")
+	}
+	if err := validateKeyAlgorithm(ca.Spec.KeyAlgorithm); err != nil {
+		return err
+	}
+	if err := validateSignerName(ca.Spec.SignerName); err != nil {
+		return err
+	}
+	return validateSubject(ca.Spec.Subject)
+}
+
+// Duration returns how long the CA cert is valid for
+func (ca CertificateAuthority) Duration() time.Duration {
+	return durationOrDefault(ca.Spec.Duration, defaultCADuration)
+}
+
+// RenewBefore returns how long before expiry the CA is regenerated
+func (ca CertificateAuthority) RenewBefore() time.Duration {
+	return renewBefore(ca.Spec.RenewBefore, ca.Duration())
+}
+
+// TrustBundle returns the CA certs of every generation of a history that hasn't
+// expired yet, newest first. Clients that trust the bundle keep accepting certs
+// issued by an earlier CA until those are reissued.
+func (ca CertificateAuthority) TrustBundle(history map[string]string, now time.Time) string {
+	var bundle strings.Builder
+	for gen := 0; gen < Generations(history); gen++ {
+		certPEM, ok := history["cacert.pem-"+strconv.Itoa(gen)]
+		if !ok {
+			continue
+		}
+		block, _ := pem.Decode([]byte(certPEM))
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || cert.NotAfter.Before(now) {
+			continue
+		}
+		bundle.WriteString(certPEM)
+	}
+	return bundle.String()
+}
+
+// NewTrustBundleSecret returns the Secret that publishes the trust bundle of a
+// CertificateAuthority. Unlike the backend Secret it holds no key, so it is the one
+// that Allowlists propagate.
+func NewTrustBundleSecret(ca *CertificateAuthority, bundle string) *corev1.Secret {
+	meta := ca.GetMeta()
+	name := strings.ToLower(meta.Kind + "-" + meta.Name + "-trust")
+	return &corev1.Secret{
+		ObjectMeta: NewG8sObjectMeta(ca, name),
+		Immutable:  boolPtr(true),
+		StringData: map[string]string{"cacert.pem": bundle},
+		Type:       "g8s.io/trust-bundle",
+	}
+}
+
+type Certificate struct {
+	v1alpha1.Certificate
+	history
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+}
+
+func NewCertificate(cert *v1alpha1.Certificate) *Certificate {
+	cert.TypeMeta = metav1.TypeMeta{
+		Kind:       "Certificate",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &Certificate{
+		Certificate: *cert,
+		history:     history{},
+	}
+}
+
+func (c Certificate) GetMeta() Meta {
+	return Meta{
+		c.TypeMeta,
+		c.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (c *Certificate) SetHistory(data map[string][]byte) {
+	c.history = newHistory(data, "key.pem", "cert.pem", "cacert.pem", KeystorePasswordField)
+}
+
+// SetCertificateAuthority loads the CA that signs the cert from the data of the
+// CertificateAuthority's backend Secret, it has to be set before Generate is called
+func (c *Certificate) SetCertificateAuthority(data map[string][]byte) error {
+	c.caCert, c.caKey = parseCA(string(data["cacert.pem"]), string(data["cakey.pem"]))
+	if c.caCert == nil {
+		return fmt.Errorf("cannot parse CA of CertificateAuthority '%s'", c.Spec.CertificateAuthorityRef)
+	}
+	return nil
+}
+
+func (c Certificate) Generate() (map[string]string, error) {
+	keyPEM, certPEM, err := issueLeaf(c.Spec.LeafCertificateSpec, c.Duration(), c.caCert, c.caKey, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]string{
 		"key.pem":    keyPEM,
 		"cert.pem":   certPEM,
-		"cacert.pem": caCertPEM,
+		"cacert.pem": encodeCert(c.caCert),
 	}
+	if c.Spec.Keystores != nil {
+		content[KeystorePasswordField] = generatePassword(c.Spec.Keystores.Password)
+	}
+	return content, nil
 }
 
-func (sstls SelfSignedTLSBundle) Rotate() map[string]string {
-	newSelfSignedTLSBundle := sstls.Generate()
-	newHistory := append([]string{newSelfSignedTLSBundle["key.pem"]}, newSelfSignedTLSBundle["cert.pem"], newSelfSignedTLSBundle["cacert.pem"])
-	newHistory = append(newHistory, sstls.history...)
-	newData := make(map[string]string)
+func (c Certificate) Rotate() (map[string]string, error) {
+	content, err := c.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return c.history.rotate(content), nil
+}
 
-	count := 0
-	hist := ""
-	for i, sstls := range newHistory {
-		mod := i % 3
+func (c Certificate) BackendContent(history map[string]string, gen int) map[string]string {
+	return leafBackendContent(c.Spec.LeafCertificateSpec, leafBackendFields(history, gen))
+}
 
-		if mod == 0 {
-			hist = "key.pem-" + strconv.Itoa(count)
-		} else if mod == 1 {
-			hist = "cert.pem-" + strconv.Itoa(count)
-		} else if mod == 2 {
-			hist = "cacert.pem-" + strconv.Itoa(count)
-			count++
-		}
-		newData[hist] = sstls
+// Validate checks the parts of the spec the CRD schema can't
+func (c Certificate) Validate() error {
+	if c.Spec.CertificateAuthorityRef == "" {
+		return fmt.Errorf("certificateAuthorityRef is required")
 	}
+	return validateLeaf(c.Spec.LeafCertificateSpec)
+}
+
+// Duration returns how long issued certs are valid for
+func (c Certificate) Duration() time.Duration {
+	return durationOrDefault(c.Spec.Duration, defaultCertDuration)
+}
+
+// RenewBefore returns how long before expiry issued certs are renewed
+func (c Certificate) RenewBefore() time.Duration {
+	return renewBefore(c.Spec.RenewBefore, c.Duration())
+}
+
+// BackendKey returns the key a field of the history is stored under in the backend
+// Secret, which depends on the output format
+func (c Certificate) BackendKey(field string) string {
+	return leafBackendKey(c.Spec.LeafCertificateSpec, field)
+}
+
+// KeystoresDrifted reports whether the keystores in the data of the backend Secret
+// differ from the ones the spec asks for
+func (c Certificate) KeystoresDrifted(data map[string][]byte) bool {
+	return keystoresDrifted(c.Spec.LeafCertificateSpec, data)
+}
+
+// SecretType returns the type of the backend Secret, which depends on the output format
+func (c Certificate) SecretType() corev1.SecretType {
+	return leafSecretType(c.Spec.LeafCertificateSpec, "g8s.io/certificate")
+}
+
+// BackendAnnotations returns cert-manager's Secret annotations if they were asked for
+func (c Certificate) BackendAnnotations() map[string]string {
+	return certManagerAnnotations(c.Spec.LeafCertificateSpec, c.Name, c.Spec.CertificateAuthorityRef, "CertificateAuthority")
+}
+
+type JWTSigningKey struct {
+	v1alpha1.JWTSigningKey
+	history
+}
+
+func NewJWTSigningKey(jwt *v1alpha1.JWTSigningKey) *JWTSigningKey {
+	jwt.TypeMeta = metav1.TypeMeta{
+		Kind:       "JWTSigningKey",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &JWTSigningKey{
+		*jwt,
+		history{},
+	}
+}
+
+func (jwt JWTSigningKey) GetMeta() Meta {
+	return Meta{
+		jwt.TypeMeta,
+		jwt.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (jwt *JWTSigningKey) SetHistory(data map[string][]byte) {
+	jwt.history = newHistory(data, "key.pem", "kid")
+}
+
+func (jwt JWTSigningKey) Generate() (map[string]string, error) {
+	key, err := generateJWTKey(jwt.Spec.Algorithm, jwt.Spec.BitSize)
+	if err != nil {
+		return nil, err
+	}
+	k, err := publicJWK(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"key.pem": encodePKCS8(key),
+		"kid":     k.Kid,
+	}, nil
+}
+
+func (jwt JWTSigningKey) Rotate() (map[string]string, error) {
+	content, err := jwt.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return jwt.history.rotate(content), nil
+}
+
+// BackendContent returns the signing key of generation gen with its kid and alg, along
+// with the JWKS of the whole history so that the signer can serve it as well
+func (jwt JWTSigningKey) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "key.pem", "kid")
+	if content == nil {
+		return nil
+	}
+
+	if key, err := parsePKCS8Key(content["key.pem"]); err == nil {
+		if k, err := publicJWK(key.Public()); err == nil {
+			content["alg"] = k.Alg
+		}
+	}
+	content["jwks.json"] = JWKS(history)
+	return content
+}
+
+// Validate checks that the spec describes a key that can be generated
+func (jwt JWTSigningKey) Validate() error {
+	return validateJWTKey(jwt.Spec.Algorithm, jwt.Spec.BitSize)
+}
+
+// NewJWKSSecret returns the Secret that publishes the JWKS of a JWTSigningKey's
+// history. Unlike the backend Secret it holds no private key, so it is the one that
+// Allowlists propagate.
+func NewJWKSSecret(jwt *JWTSigningKey, history map[string]string) *corev1.Secret {
+	meta := jwt.GetMeta()
+	name := strings.ToLower(meta.Kind + "-" + meta.Name + "-jwks")
+	return &corev1.Secret{
+		ObjectMeta: NewG8sObjectMeta(jwt, name),
+		Immutable:  boolPtr(true),
+		StringData: map[string]string{"jwks.json": JWKS(history)},
+		Type:       "g8s.io/jwks",
+	}
+}
+
+type RandomSecret struct {
+	v1alpha1.RandomSecret
+	history
+}
+
+func NewRandomSecret(rs *v1alpha1.RandomSecret) *RandomSecret {
+	rs.TypeMeta = metav1.TypeMeta{
+		Kind:       "RandomSecret",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &RandomSecret{
+		*rs,
+		history{},
+	}
+}
+
+func (rs RandomSecret) GetMeta() Meta {
+	return Meta{
+		rs.TypeMeta,
+		rs.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history. The keys of a RandomSecret can
+// change between generations, so every key found in the history is loaded.
+func (rs *RandomSecret) SetHistory(data map[string][]byte) {
+	content := StringData(data)
+	fields := []string{metaField}
+	for gen := 0; gen < Generations(content); gen++ {
+		for _, f := range generationFields(content, gen) {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	rs.history = newHistory(data, fields...)
+}
+
+func (rs RandomSecret) Generate() (map[string]string, error) {
+	content := make(map[string]string)
+	for _, k := range rs.keys() {
+		v, err := randomString(k.Bytes, k.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		content[k.Name] = v
+	}
+	return content, nil
+}
+
+func (rs RandomSecret) Rotate() (map[string]string, error) {
+	content, err := rs.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return rs.history.rotate(content), nil
+}
+
+// BackendContent returns every key of generation gen, which are the keys the spec
+// listed when the generation was created
+func (rs RandomSecret) BackendContent(history map[string]string, gen int) map[string]string {
+	fields := generationFields(history, gen)
+	if len(fields) == 0 {
+		return nil
+	}
+	return generation(history, gen, fields...)
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (rs RandomSecret) Validate() error {
+	var names []string
+	for _, k := range rs.keys() {
+		if slices.Contains(names, k.Name) {
+			return fmt.Errorf("duplicate key name %q", k.Name)
+		}
+		names = append(names, k.Name)
+
+		if err := validateRandomKey(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keys returns the keys of the spec with spec.bytes and spec.encoding, or their
+// defaults, filled in where a key doesn't override them
+func (rs RandomSecret) keys() []v1alpha1.RandomSecretKey {
+	keys := rs.Spec.Keys
+	if len(keys) == 0 {
+		keys = []v1alpha1.RandomSecretKey{{Name: defaultRandomSecretKey}}
+	}
+
+	filled := make([]v1alpha1.RandomSecretKey, 0, len(keys))
+	for _, k := range keys {
+		if k.Bytes == 0 {
+			k.Bytes = rs.Spec.Bytes
+		}
+		if k.Bytes == 0 {
+			k.Bytes = defaultRandomBytes
+		}
+		if k.Encoding == "" {
+			k.Encoding = rs.Spec.Encoding
+		}
+		filled = append(filled, k)
+	}
+	return filled
+}
+
+type APIToken struct {
+	v1alpha1.APIToken
+	history
+}
+
+func NewAPIToken(t *v1alpha1.APIToken) *APIToken {
+	t.TypeMeta = metav1.TypeMeta{
+		Kind:       "APIToken",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &APIToken{
+		*t,
+		history{},
+	}
+}
+
+func (t APIToken) GetMeta() Meta {
+	return Meta{
+		t.TypeMeta,
+		t.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (t *APIToken) SetHistory(data map[string][]byte) {
+	t.history = newHistory(data, "token")
+}
+
+func (t APIToken) Generate() (map[string]string, error) {
+	length := t.Spec.Length
+	if length == 0 {
+		length = defaultAPITokenLength
+	}
+	token, err := newAPIToken(t.Spec.Prefix, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"token": token,
+	}, nil
+}
+
+func (t APIToken) Rotate() (map[string]string, error) {
+	content, err := t.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return t.history.rotate(content), nil
+}
+
+// BackendContent returns the token of generation gen and its digest, along with the
+// digests of every token that is still valid, one per line and newest first. That is
+// the token itself and the tokens it replaced less than the grace period ago.
+func (t APIToken) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "token")
+	if content == nil {
+		return nil
+	}
+
+	digests := []string{tokenDigest(content["token"])}
+	for i := range t.GracePeriodExpiries(history, gen, time.Now()) {
+		digests = append(digests, tokenDigest(history["token-"+strconv.Itoa(gen+i+1)]))
+	}
+	content["token.sha256"] = digests[0]
+	content["valid-digests"] = strings.Join(digests, "\n") + "\n"
+	return content
+}
+
+// GracePeriodExpiries returns when each of the tokens replaced by generation gen, or
+// by the generations it replaced in turn, stops being valid, newest first. Only the
+// tokens that are still valid at now are included, and since each one expires after
+// the one it replaced, the last is the next to expire.
+func (t APIToken) GracePeriodExpiries(history map[string]string, gen int, now time.Time) []time.Time {
+	var expiries []time.Time
+	for g := gen; ; g++ {
+		if _, ok := history["token-"+strconv.Itoa(g+1)]; !ok {
+			return expiries
+		}
+		meta := GetGenerationMeta(history, g)
+		if meta == nil {
+			return expiries
+		}
+
+		expiry := meta.CreatedAt.Add(t.GracePeriod())
+		if !expiry.After(now) {
+			return expiries
+		}
+		expiries = append(expiries, expiry)
+	}
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (t APIToken) Validate() error {
+	return validateAPIToken(t.Spec)
+}
+
+// GracePeriod returns how long replaced tokens stay valid
+func (t APIToken) GracePeriod() time.Duration {
+	if t.Spec.GracePeriod != nil && t.Spec.GracePeriod.Duration >= 0 {
+		return t.Spec.GracePeriod.Duration
+	}
+	return defaultAPITokenGracePeriod
+}
+
+type RegistryCredential struct {
+	v1alpha1.RegistryCredential
+	history
+}
+
+func NewRegistryCredential(r *v1alpha1.RegistryCredential) *RegistryCredential {
+	r.TypeMeta = metav1.TypeMeta{
+		Kind:       "RegistryCredential",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &RegistryCredential{
+		*r,
+		history{},
+	}
+}
+
+func (r RegistryCredential) GetMeta() Meta {
+	return Meta{
+		r.TypeMeta,
+		r.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (r *RegistryCredential) SetHistory(data map[string][]byte) {
+	r.history = newHistory(data, "password", "username", "htpasswd")
+}
+
+// Generate uses the same password generator as a Login. The username is kept with
+// each generation so that its htpasswd entry and dockerconfigjson always agree, a
+// new username applies from the next rotation on.
+func (r RegistryCredential) Generate() (map[string]string, error) {
+	pw := generatePassword(r.Spec.Password)
+	htpasswd, err := htpasswdEntry(r.Spec.Username, pw)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"password": pw,
+		"username": r.Spec.Username,
+		"htpasswd": htpasswd,
+	}, nil
+}
+
+func (r RegistryCredential) Rotate() (map[string]string, error) {
+	content, err := r.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return r.history.rotate(content), nil
+}
+
+func (r RegistryCredential) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "password", "username", "htpasswd")
+	if content != nil {
+		content[corev1.DockerConfigJsonKey] = dockerConfigJSON(r.Spec.Server, content["username"], content["password"], r.Spec.Email)
+	}
+	return content
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (r RegistryCredential) Validate() error {
+	return validateRegistryCredential(r.Spec)
+}
+
+type WireGuardKeyPair struct {
+	v1alpha1.WireGuardKeyPair
+	history
+	peers []WireGuardPeer
+}
+
+func NewWireGuardKeyPair(wg *v1alpha1.WireGuardKeyPair) *WireGuardKeyPair {
+	wg.TypeMeta = metav1.TypeMeta{
+		Kind:       "WireGuardKeyPair",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &WireGuardKeyPair{
+		*wg,
+		history{},
+		nil,
+	}
+}
+
+func (wg WireGuardKeyPair) GetMeta() Meta {
+	return Meta{
+		wg.TypeMeta,
+		wg.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (wg *WireGuardKeyPair) SetHistory(data map[string][]byte) {
+	wg.history = newHistory(data, "private.key", "public.key", "preshared.key")
+}
+
+// SetPeers sets the peers the wg0.conf in the backend Secret is rendered with, the
+// ones of spec.peers that are ready
+func (wg *WireGuardKeyPair) SetPeers(peers []WireGuardPeer) {
+	wg.peers = peers
+}
+
+func (wg WireGuardKeyPair) Generate() (map[string]string, error) {
+	privateKey, publicKey, err := newWireGuardKey()
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]string{
+		"private.key": privateKey,
+		"public.key":  publicKey,
+	}
+	if wg.Spec.PresharedKey {
+		psk, err := newWireGuardPresharedKey()
+		if err != nil {
+			return nil, err
+		}
+		content["preshared.key"] = psk
+	}
+
+	return content, nil
+}
+
+func (wg WireGuardKeyPair) Rotate() (map[string]string, error) {
+	content, err := wg.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return wg.history.rotate(content), nil
+}
+
+// BackendContent returns the keys of generation gen, and a wg0.conf if spec.peers
+// is set
+func (wg WireGuardKeyPair) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "private.key", "public.key")
+	if content == nil {
+		return nil
+	}
+
+	if optional := generation(history, gen, "preshared.key"); optional != nil {
+		content["preshared.key"] = optional["preshared.key"]
+	}
+	if len(wg.Spec.Peers) > 0 {
+		content["wg0.conf"] = wireGuardConfig(wg.Spec, wg.Name, content["private.key"], content["preshared.key"], wg.peers)
+	}
+	return content
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (wg WireGuardKeyPair) Validate() error {
+	return validateWireGuardKeyPair(wg.Name, wg.Spec)
+}
+
+type PGPKeyPair struct {
+	v1alpha1.PGPKeyPair
+	history
+}
+
+func NewPGPKeyPair(pgp *v1alpha1.PGPKeyPair) *PGPKeyPair {
+	pgp.TypeMeta = metav1.TypeMeta{
+		Kind:       "PGPKeyPair",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &PGPKeyPair{
+		*pgp,
+		history{},
+	}
+}
+
+func (pgp PGPKeyPair) GetMeta() Meta {
+	return Meta{
+		pgp.TypeMeta,
+		pgp.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (pgp *PGPKeyPair) SetHistory(data map[string][]byte) {
+	pgp.history = newHistory(data, "private.asc", "public.asc", "passphrase")
+}
+
+func (pgp PGPKeyPair) Generate() (map[string]string, error) {
+	var passphrase string
+	if pgp.Spec.Passphrase != nil {
+		passphrase = generatePassword(pgp.Spec.Passphrase)
+	}
+	private, public, err := newPGPKey(pgp.Spec, pgp.Expiry(), passphrase, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]string{
+		"private.asc": private,
+		"public.asc":  public,
+	}
+	if passphrase != "" {
+		content["passphrase"] = passphrase
+	}
+	return content, nil
+}
+
+func (pgp PGPKeyPair) Rotate() (map[string]string, error) {
+	content, err := pgp.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return pgp.history.rotate(content), nil
+}
+
+// Renew extends the expiry of the newest key in the history rather than generating
+// a new one. A key that can't be renewed, e.g. because the history is empty, is
+// replaced by a new key like in a rotation.
+func (pgp PGPKeyPair) Renew() (map[string]string, error) {
+	if len(pgp.history) == 0 {
+		return pgp.Rotate()
+	}
+
+	newest := make(map[string]string)
+	for f, v := range pgp.history[0] {
+		if f != metaField {
+			newest[f] = v
+		}
+	}
+	private, public, err := renewPGPKey(newest["private.asc"], pgp.Expiry(), newest["passphrase"], time.Now())
+	if err != nil {
+		return pgp.Rotate()
+	}
+	newest["private.asc"], newest["public.asc"] = private, public
+	return pgp.history.rotate(newest), nil
+}
+
+func (pgp PGPKeyPair) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "private.asc", "public.asc")
+	if content == nil {
+		return nil
+	}
+
+	if optional := generation(history, gen, "passphrase"); optional != nil {
+		content["passphrase"] = optional["passphrase"]
+	}
+	content["fingerprint"] = PGPFingerprint([]byte(content["public.asc"]))
+	return content
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (pgp PGPKeyPair) Validate() error {
+	return validatePGPKey(pgp.Spec)
+}
+
+// Expiry returns how long keys are valid for after they're created or renewed, 0 if
+// they don't expire
+func (pgp PGPKeyPair) Expiry() time.Duration {
+	if pgp.Spec.Expiry != nil && pgp.Spec.Expiry.Duration >= 0 {
+		return pgp.Spec.Expiry.Duration
+	}
+	return defaultPGPExpiry
+}
+
+// RenewBefore returns how long before expiry keys are renewed
+func (pgp PGPKeyPair) RenewBefore() time.Duration {
+	return renewBefore(pgp.Spec.RenewBefore, pgp.Expiry())
+}
+
+type AgeKeyPair struct {
+	v1alpha1.AgeKeyPair
+	history
+}
+
+func NewAgeKeyPair(akp *v1alpha1.AgeKeyPair) *AgeKeyPair {
+	akp.TypeMeta = metav1.TypeMeta{
+		Kind:       "AgeKeyPair",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &AgeKeyPair{
+		*akp,
+		history{},
+	}
+}
+
+func (akp AgeKeyPair) GetMeta() Meta {
+	return Meta{
+		akp.TypeMeta,
+		akp.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (akp *AgeKeyPair) SetHistory(data map[string][]byte) {
+	akp.history = newHistory(data, "identity", "recipient")
+}
+
+func (akp AgeKeyPair) Generate() (map[string]string, error) {
+	identity, recipient, err := newAgeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"identity":  identity,
+		"recipient": recipient,
+	}, nil
+}
+
+func (akp AgeKeyPair) Rotate() (map[string]string, error) {
+	content, err := akp.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return akp.history.rotate(content), nil
+}
+
+// BackendContent returns the identity and recipient of generation gen, along with
+// a keys.txt of the identity
+func (akp AgeKeyPair) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "identity", "recipient")
+	if content == nil {
+		return nil
+	}
+
+	content["keys.txt"] = ageKeysFile(content["identity"], content["recipient"])
+	return content
+}
+
+// NewAgeRecipientSecret returns the Secret that publishes the recipient of an
+// AgeKeyPair's live generation. Unlike the backend Secret it holds no identity, so
+// it is the one that Allowlists propagate to consumers that only encrypt.
+func NewAgeRecipientSecret(akp *AgeKeyPair, recipient string) *corev1.Secret {
+	meta := akp.GetMeta()
+	name := strings.ToLower(meta.Kind + "-" + meta.Name + "-recipient")
+	return &corev1.Secret{
+		ObjectMeta: NewG8sObjectMeta(akp, name),
+		Immutable:  boolPtr(true),
+		StringData: map[string]string{"recipient": recipient},
+		Type:       "g8s.io/age-recipient",
+	}
+}
+
+type TOTPSeed struct {
+	v1alpha1.TOTPSeed
+	history
+}
+
+func NewTOTPSeed(ts *v1alpha1.TOTPSeed) *TOTPSeed {
+	ts.TypeMeta = metav1.TypeMeta{
+		Kind:       "TOTPSeed",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &TOTPSeed{
+		*ts,
+		history{},
+	}
+}
+
+func (ts TOTPSeed) GetMeta() Meta {
+	return Meta{
+		ts.TypeMeta,
+		ts.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (ts *TOTPSeed) SetHistory(data map[string][]byte) {
+	ts.history = newHistory(data, "secret")
+}
+
+func (ts TOTPSeed) Generate() (map[string]string, error) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"secret": secret,
+	}, nil
+}
+
+func (ts TOTPSeed) Rotate() (map[string]string, error) {
+	content, err := ts.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return ts.history.rotate(content), nil
+}
+
+// BackendContent returns the seed of generation gen along with its otpauth URI and
+// a QR code of it, both rendered with the current spec
+func (ts TOTPSeed) BackendContent(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "secret")
+	if content == nil {
+		return nil
+	}
+
+	key, err := totpKey(ts.Spec, content["secret"])
+	if err != nil {
+		return content
+	}
+	content["otpauth-uri"] = key.URL()
+	if qr, err := totpQRCode(key); err == nil {
+		content["qr.png"] = qr
+	}
+	return content
+}
+
+// Validate checks the parts of the spec the CRD schema can't
+func (ts TOTPSeed) Validate() error {
+	return validateTOTPSeed(ts.Spec)
+}
+
+type DHParams struct {
+	v1alpha1.DHParams
+	history
+}
+
+func NewDHParams(dh *v1alpha1.DHParams) *DHParams {
+	dh.TypeMeta = metav1.TypeMeta{
+		Kind:       "DHParams",
+		APIVersion: "api.g8s.io/v1alpha1",
+	}
+	return &DHParams{
+		*dh,
+		history{},
+	}
+}
+
+func (dh DHParams) GetMeta() Meta {
+	return Meta{
+		dh.TypeMeta,
+		dh.ObjectMeta,
+	}
+}
+
+// SetHistory loads the generations of an existing history Secret so that Rotate
+// prepends to them instead of starting a new history
+func (dh *DHParams) SetHistory(data map[string][]byte) {
+	dh.history = newHistory(data, "dhparam.pem")
+}
+
+// Generate blocks until new parameters are computed, which takes minutes for large
+// bit sizes. The controller calls Compute off its workers instead.
+func (dh DHParams) Generate() (map[string]string, error) {
+	return dh.Compute(context.Background())
+}
+
+// Compute generates new parameters for the spec, giving up once ctx is done
+func (dh DHParams) Compute(ctx context.Context) (map[string]string, error) {
+	bits, generator := dhParamsSize(dh.Spec)
+	params, err := newDHParams(ctx, bits, generator)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"dhparam.pem": params,
+	}, nil
+}
+
+func (dh DHParams) Rotate() (map[string]string, error) {
+	content, err := dh.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return dh.RotateTo(content), nil
+}
+
+// RotateTo returns the content of the history Secret with content, parameters
+// returned by Compute, prepended as generation 0
+func (dh DHParams) RotateTo(content map[string]string) map[string]string {
+	return dh.history.rotate(content)
+}
+
+func (dh DHParams) BackendContent(history map[string]string, gen int) map[string]string {
+	return generation(history, gen, "dhparam.pem")
+}
 
-	return newData
+// Validate checks the parts of the spec the CRD schema can't
+func (dh DHParams) Validate() error {
+	return validateDHParams(dh.Spec)
 }
@@ -0,0 +1,212 @@
+package v1alpha1
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/GehirnInc/crypt/sha512_crypt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// argon2id parameters, the second recommended option of RFC 9106
+const (
+	argon2idTime    = 3
+	argon2idMemory  = 64 * 1024
+	argon2idThreads = 4
+	argon2idKeyLen  = 32
+)
+
+// scramIterations is the iteration count of SCRAM-SHA-256 verifiers, the one Postgres
+// uses
+const scramIterations = 4096
+
+// passwordHashFields are the fields of a history generation each hash is kept in.
+// htpasswd lines are rendered from the bcrypt hash so that they follow spec.username.
+var passwordHashFields = map[v1alpha1.PasswordHash]string{
+	v1alpha1.PasswordHashBcrypt:      "bcrypt",
+	v1alpha1.PasswordHashArgon2id:    "argon2id",
+	v1alpha1.PasswordHashSHA512Crypt: "sha512crypt",
+	v1alpha1.PasswordHashSCRAMSHA256: "scram-sha-256",
+	v1alpha1.PasswordHashHtpasswd:    "bcrypt",
+}
+
+// PasswordHashFields are the history fields password hashes are kept in, next to the
+// password of their generation
+var PasswordHashFields = []string{"bcrypt", "argon2id", "sha512crypt", "scram-sha-256"}
+
+// PasswordHashKeys are the keys password hashes can have in the backend Secret of a
+// Login
+var PasswordHashKeys = []string{"bcrypt", "argon2id", "sha512crypt", "scram-sha-256", "htpasswd"}
+
+// validatePasswordHashes checks that spec only asks for hashes that can be computed
+// for its passwords
+func validatePasswordHashes(spec *v1alpha1.PasswordSpec) error {
+	if spec == nil {
+		return nil
+	}
+	for _, h := range spec.Hashes {
+		field, ok := passwordHashFields[h]
+		if !ok {
+			return fmt.Errorf("unsupported password hash %q", h)
+		}
+		// bcrypt only hashes the first 72 bytes, x/crypto refuses longer passwords
+		if field == "bcrypt" && spec.Length > 72 {
+			return fmt.Errorf("%s needs passwords of at most 72 characters, got length %d", h, spec.Length)
+		}
+	}
+	return nil
+}
+
+// passwordHashFieldsOf returns the history fields of the hashes spec asks for
+func passwordHashFieldsOf(spec *v1alpha1.PasswordSpec) []string {
+	var fields []string
+	if spec == nil {
+		return fields
+	}
+	for _, h := range spec.Hashes {
+		if f, ok := passwordHashFields[h]; ok && !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// hashPassword hashes password into the format of a history field
+func hashPassword(field, password string) (string, error) {
+	switch field {
+	case "bcrypt":
+		b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		return string(b), err
+	case "argon2id":
+		return argon2idHash(password)
+	case "sha512crypt":
+		// an empty salt has a random one generated, with the default 5000 rounds
+		return sha512_crypt.New().Generate([]byte(password), nil)
+	case "scram-sha-256":
+		return scramSHA256Verifier(password)
+	default:
+		return "", fmt.Errorf("unsupported password hash %q", field)
+	}
+}
+
+// argon2idHash returns an argon2id hash of password in the PHC string format that
+// libargon2 and most argon2 libraries verify
+func argon2idHash(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2idTime, argon2idMemory, argon2idThreads, argon2idKeyLen)
+	b64 := base64.RawStdEncoding.EncodeToString
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, argon2idMemory, argon2idTime, argon2idThreads, b64(salt), b64(key)), nil
+}
+
+// scramSHA256Verifier returns the SCRAM-SHA-256 verifier of password, RFC 7677, in
+// the format Postgres stores in pg_authid and accepts in CREATE ROLE ... PASSWORD.
+// Generated passwords are ASCII, which SASLprep leaves as they are.
+func scramSHA256Verifier(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return scramSHA256VerifierWithSalt(password, salt, scramIterations), nil
+}
+
+// scramSHA256VerifierWithSalt returns the SCRAM-SHA-256 verifier of password for a
+// given salt and iteration count
+func scramSHA256VerifierWithSalt(password string, salt []byte, iterations int) string {
+	salted := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+	clientKey := scramHMAC(salted, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := scramHMAC(salted, "Server Key")
+
+	b64 := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("SCRAM-SHA-256$%d:%s$%s:%s", iterations, b64(salt), b64(storedKey[:]), b64(serverKey))
+}
+
+func scramHMAC(key []byte, msg string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
+
+// completePasswordHashes returns a copy of history with the hashes spec asks for
+// added to generation gen, and whether any were missing. A generation is missing
+// hashes when it's from before spec.password.hashes asked for them. Hashes are salted,
+// so they're computed once here and kept in the history rather than recomputed
+// whenever the backend Secret is.
+func completePasswordHashes(spec *v1alpha1.PasswordSpec, history map[string]string, gen int) (map[string]string, bool, error) {
+	completed := maps.Clone(history)
+	password, ok := history["password-"+strconv.Itoa(gen)]
+	if !ok {
+		return completed, false, nil
+	}
+
+	added := false
+	for _, field := range passwordHashFieldsOf(spec) {
+		key := field + "-" + strconv.Itoa(gen)
+		if _, ok := completed[key]; ok {
+			continue
+		}
+		hash, err := hashPassword(field, password)
+		if err != nil {
+			return nil, false, err
+		}
+		completed[key] = hash
+		added = true
+	}
+	return completed, added, nil
+}
+
+// addPasswordHashes adds the hashes spec asks for to content, the password and
+// hashes of a generation, under their keys in the backend Secret. Hashes are only
+// read from the history; ones generation gen doesn't have are left out until
+// completePasswordHashes adds them.
+func addPasswordHashes(spec *v1alpha1.PasswordSpec, username string, history map[string]string, gen int, content map[string]string) {
+	if spec == nil {
+		return
+	}
+	for _, h := range spec.Hashes {
+		field, ok := passwordHashFields[h]
+		if !ok {
+			continue
+		}
+		hash, ok := history[field+"-"+strconv.Itoa(gen)]
+		if !ok {
+			continue
+		}
+
+		if h == v1alpha1.PasswordHashHtpasswd {
+			content[string(h)] = username + ":" + hash + "\n"
+		} else {
+			content[string(h)] = hash
+		}
+	}
+}
+
+// passwordHashesDrifted reports whether the hashes in the data of a backend Secret
+// differ from the ones spec asks for
+func passwordHashesDrifted(spec *v1alpha1.PasswordSpec, data map[string][]byte) bool {
+	var want []v1alpha1.PasswordHash
+	if spec != nil {
+		want = spec.Hashes
+	}
+	for _, k := range PasswordHashKeys {
+		_, ok := data[k]
+		if ok != slices.Contains(want, v1alpha1.PasswordHash(k)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+func TestSCRAMSHA256Verifier(t *testing.T) {
+	// computed independently with Python's hashlib.pbkdf2_hmac and hmac
+	salt, err := base64.StdEncoding.DecodeString("3gp8qnYumwfzLHRUlzkSkA==")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SCRAM-SHA-256$4096:3gp8qnYumwfzLHRUlzkSkA==$x/bZfJfLedKgg1VGbX12PLN1NOzC0pAIvDg15CN+2QM=:GoZNDhd9uyjMp8Vxue1zXKMIIsh2CQoTpQ42c8dhHGE="
+
+	got := scramSHA256VerifierWithSalt("G2wqJkWBHgxISICDfIYr6elT791JEeEl", salt, 4096)
+	if got != want {
+		t.Errorf("scramSHA256VerifierWithSalt() = %q, want %q", got, want)
+	}
+}
+
+func TestCompletePasswordHashes(t *testing.T) {
+	spec := &v1alpha1.PasswordSpec{
+		Length: 16,
+		Hashes: []v1alpha1.PasswordHash{v1alpha1.PasswordHashBcrypt, v1alpha1.PasswordHashHtpasswd, v1alpha1.PasswordHashSCRAMSHA256},
+	}
+	history := map[string]string{
+		"password-0":      "0123456789abcdef",
+		"scram-sha-256-0": "SCRAM-SHA-256$kept",
+		"password-1":      "fedcba9876543210",
+	}
+
+	completed, added, err := completePasswordHashes(spec, history, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added {
+		t.Fatal("completePasswordHashes() added = false, want true")
+	}
+	if _, ok := history["bcrypt-0"]; ok {
+		t.Error("completePasswordHashes() modified its input")
+	}
+	if completed["scram-sha-256-0"] != "SCRAM-SHA-256$kept" {
+		t.Errorf("scram-sha-256-0 = %q, want the existing hash kept", completed["scram-sha-256-0"])
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(completed["bcrypt-0"]), []byte("0123456789abcdef")); err != nil {
+		t.Errorf("bcrypt-0 doesn't match the password of generation 0: %v", err)
+	}
+	if _, ok := completed["bcrypt-1"]; ok {
+		t.Error("completePasswordHashes() hashed a generation other than gen")
+	}
+
+	// a complete generation has nothing added, and the backend content is read
+	// from the history rather than rehashed
+	again, added, err := completePasswordHashes(spec, completed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added {
+		t.Error("completePasswordHashes() added = true for a complete generation")
+	}
+	content := map[string]string{"password": again["password-0"]}
+	addPasswordHashes(spec, "admin", again, 0, content)
+	if content["bcrypt"] != completed["bcrypt-0"] {
+		t.Errorf("bcrypt = %q, want %q from the history", content["bcrypt"], completed["bcrypt-0"])
+	}
+	if content["htpasswd"] != "admin:"+completed["bcrypt-0"]+"\n" {
+		t.Errorf("htpasswd = %q, want it rendered from the bcrypt hash", content["htpasswd"])
+	}
+
+	// generations missing hashes are left without them rather than hashed anew
+	content = map[string]string{"password": history["password-1"]}
+	addPasswordHashes(spec, "admin", history, 1, content)
+	for _, k := range PasswordHashKeys {
+		if v, ok := content[k]; ok {
+			t.Errorf("%s = %q, want no hash for a generation without one", k, v)
+		}
+	}
+	if !strings.HasPrefix(completed["bcrypt-0"], "$2a$") {
+		t.Errorf("bcrypt-0 = %q, want a bcrypt hash", completed["bcrypt-0"])
+	}
+}
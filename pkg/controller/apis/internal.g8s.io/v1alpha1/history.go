@@ -0,0 +1,181 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metaField is the field of each generation in a history Secret that holds its
+// GenerationMeta as JSON
+const metaField = "meta"
+
+// reasons a generation was added to a history
+const (
+	ReasonCreated   = "Created"
+	ReasonRebuilt   = "Rebuilt"
+	ReasonScheduled = "Scheduled"
+	ReasonRequested = "Requested"
+	ReasonRenewed   = "Renewed"
+
+	// ReasonCARotation marks the generations of the phases of a CA rotation
+	ReasonCARotation = "CARotation"
+)
+
+// GenerationMeta records when and why a generation of a history was created
+type GenerationMeta struct {
+	CreatedAt metav1.Time `json:"createdAt"`
+	Reason    string      `json:"reason"`
+
+	// TriggeredBy is what caused the generation, e.g. spec.rotation or the
+	// annotation and value that requested it
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+
+	// Manager is the field manager that last set the triggering annotation or
+	// created the object, usually the closest thing to a user we can know of
+	Manager string `json:"manager,omitempty"`
+}
+
+// history is the list of generations stored in a history Secret, newest first. Each
+// generation maps field names to values, along with its metadata under "meta".
+type history []map[string]string
+
+// generation picks the given fields of generation gen out of a history, or returns
+// nil if the history doesn't have that generation
+func generation(history map[string]string, gen int, fields ...string) map[string]string {
+	content := make(map[string]string)
+	for _, f := range fields {
+		v, ok := history[f+"-"+strconv.Itoa(gen)]
+		if !ok {
+			return nil
+		}
+		content[f] = v
+	}
+	return content
+}
+
+// generationFields returns the names of the fields of generation gen of a history,
+// for types whose fields aren't fixed
+func generationFields(history map[string]string, gen int) []string {
+	var fields []string
+	for k := range history {
+		if g, ok := keyGeneration(k); ok && g == gen {
+			if f := k[:strings.LastIndex(k, "-")]; f != metaField {
+				fields = append(fields, f)
+			}
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// newHistory reads the generations out of a history Secret's data, fields[0] has to
+// be present in every generation
+func newHistory(data map[string][]byte, fields ...string) history {
+	h := history{}
+	for i := 0; ; i++ {
+		gen := "-" + strconv.Itoa(i)
+		if _, ok := data[fields[0]+gen]; !ok {
+			return h
+		}
+
+		g := make(map[string]string)
+		for _, f := range append(fields, metaField) {
+			if v, ok := data[f+gen]; ok {
+				g[f] = string(v)
+			}
+		}
+		h = append(h, g)
+	}
+}
+
+// rotate returns the content of a history Secret with newest prepended to h as
+// generation 0
+func (h history) rotate(newest map[string]string) map[string]string {
+	content := make(map[string]string)
+	for i, g := range append(history{newest}, h...) {
+		for f, v := range g {
+			content[f+"-"+strconv.Itoa(i)] = v
+		}
+	}
+	return content
+}
+
+// SetGenerationMeta stores meta as the metadata of generation gen of a history
+func SetGenerationMeta(history map[string]string, gen int, meta GenerationMeta) {
+	b, _ := json.Marshal(meta)
+	history[metaField+"-"+strconv.Itoa(gen)] = string(b)
+}
+
+// GetGenerationMeta returns the metadata of generation gen of a history, or nil if
+// it has none, e.g. because it predates metadata being recorded
+func GetGenerationMeta(history map[string]string, gen int) *GenerationMeta {
+	v, ok := history[metaField+"-"+strconv.Itoa(gen)]
+	if !ok {
+		return nil
+	}
+
+	meta := &GenerationMeta{}
+	if err := json.Unmarshal([]byte(v), meta); err != nil {
+		return nil
+	}
+	return meta
+}
+
+// Generations returns the number of generations in a history
+func Generations(history map[string]string) int {
+	gens := 0
+	for k := range history {
+		if gen, ok := keyGeneration(k); ok && gen+1 > gens {
+			gens = gen + 1
+		}
+	}
+	return gens
+}
+
+// PruneHistory drops the oldest generations of a history that are beyond maxEntries
+// or older than maxAge, a limit of 0 means unlimited. Generation 0 and the live
+// generation, along with everything newer than it, are always kept. It returns the
+// pruned history and the number of generations left.
+func PruneHistory(history map[string]string, maxEntries int, maxAge time.Duration, live int, now time.Time) (map[string]string, int) {
+	keep := Generations(history)
+	for keep > 1 && keep > live+1 {
+		gen := keep - 1
+		expired := false
+		if maxAge > 0 {
+			meta := GetGenerationMeta(history, gen)
+			expired = meta != nil && meta.CreatedAt.Add(maxAge).Before(now)
+		}
+
+		if !expired && (maxEntries <= 0 || gen < maxEntries) {
+			break
+		}
+		keep--
+	}
+
+	pruned := make(map[string]string)
+	for k, v := range history {
+		if gen, ok := keyGeneration(k); !ok || gen < keep {
+			pruned[k] = v
+		}
+	}
+	return pruned, keep
+}
+
+// keyGeneration parses the generation out of a history key like password-3
+func keyGeneration(key string) (int, bool) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return 0, false
+	}
+
+	gen, err := strconv.Atoi(key[i+1:])
+	if err != nil || gen < 0 {
+		return 0, false
+	}
+	return gen, true
+}
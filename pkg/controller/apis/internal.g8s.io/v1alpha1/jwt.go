@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// defaultJWTBitSize is the size of RS256 keys unless spec.bitSize says otherwise
+const defaultJWTBitSize = 2048
+
+// jwk is the public half of a signing key as a JSON Web Key, RFC 7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// jwkSet is a JSON Web Key Set, the document verifiers fetch their keys from
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// validateJWTKey checks that algorithm and bitSize describe a key that can be generated
+func validateJWTKey(algorithm v1alpha1.JWTAlgorithm, bitSize int) error {
+	switch algorithm {
+	case "", v1alpha1.JWTAlgorithmRS256:
+		if bitSize != 0 && bitSize < 2048 {
+			return fmt.Errorf("bitSize of RS256 keys must be at least 2048, got %d", bitSize)
+		}
+		return nil
+	case v1alpha1.JWTAlgorithmES256, v1alpha1.JWTAlgorithmEdDSA:
+		if bitSize != 0 {
+			return fmt.Errorf("bitSize is only supported for RS256 keys")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+// generateJWTKey generates a signing key for algorithm, RS256 if empty
+func generateJWTKey(algorithm v1alpha1.JWTAlgorithm, bitSize int) (crypto.Signer, error) {
+	switch algorithm {
+	case "", v1alpha1.JWTAlgorithmRS256:
+		if bitSize == 0 {
+			bitSize = defaultJWTBitSize
+		}
+		return rsa.GenerateKey(rand.Reader, bitSize)
+	case v1alpha1.JWTAlgorithmES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case v1alpha1.JWTAlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+// parsePKCS8Key parses a PEM encoded PKCS #8 private key
+func parsePKCS8Key(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+// publicJWK returns the public key of a signing key as a JWK. The algorithm is
+// derived from the key, so keys keep theirs after spec.algorithm changes, and the kid
+// is the key's RFC 7638 thumbprint.
+func publicJWK(pub crypto.PublicKey) (jwk, error) {
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	var k jwk
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		k = jwk{Kty: "RSA", Alg: string(v1alpha1.JWTAlgorithmRS256), N: b64(p.N.Bytes()), E: b64(big.NewInt(int64(p.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		if p.Curve != elliptic.P256() {
+			return jwk{}, fmt.Errorf("unsupported curve %s", p.Curve.Params().Name)
+		}
+		k = jwk{Kty: "EC", Alg: string(v1alpha1.JWTAlgorithmES256), Crv: "P-256", X: b64(p.X.FillBytes(make([]byte, 32))), Y: b64(p.Y.FillBytes(make([]byte, 32)))}
+	case ed25519.PublicKey:
+		k = jwk{Kty: "OKP", Alg: string(v1alpha1.JWTAlgorithmEdDSA), Crv: "Ed25519", X: b64(p)}
+	default:
+		return jwk{}, fmt.Errorf("unsupported key type %T", pub)
+	}
+	k.Use = "sig"
+	k.Kid = jwkThumbprint(k)
+	return k, nil
+}
+
+// jwkThumbprint returns the RFC 7638 thumbprint of k, the SHA-256 of its required
+// members in lexicographic order, which json.Marshal sorts map keys in
+func jwkThumbprint(k jwk) string {
+	members := map[string]string{"kty": k.Kty}
+	switch k.Kty {
+	case "RSA":
+		members["n"], members["e"] = k.N, k.E
+	case "EC":
+		members["crv"], members["x"], members["y"] = k.Crv, k.X, k.Y
+	case "OKP":
+		members["crv"], members["x"] = k.Crv, k.X
+	}
+
+	b, _ := json.Marshal(members)
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// JWKS returns the JWKS with the public keys of every generation of a history,
+// newest first. Verifiers that fetch it keep accepting tokens signed by an earlier
+// key until that generation is pruned from the history.
+func JWKS(history map[string]string) string {
+	set := jwkSet{Keys: []jwk{}}
+	var kids []string
+	for gen := 0; gen < Generations(history); gen++ {
+		keyPEM, ok := history["key.pem-"+strconv.Itoa(gen)]
+		if !ok {
+			continue
+		}
+		key, err := parsePKCS8Key(keyPEM)
+		if err != nil {
+			continue
+		}
+		k, err := publicJWK(key.Public())
+		if err != nil || slices.Contains(kids, k.Kid) {
+			continue
+		}
+		kids = append(kids, k.Kid)
+		set.Keys = append(set.Keys, k)
+	}
+
+	b, _ := json.Marshal(set)
+	return string(b)
+}
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// keyAlgorithms are the algorithms generateKey supports, empty being ECDSA P-256
+var keyAlgorithms = []v1alpha1.KeyAlgorithm{
+	"",
+	v1alpha1.KeyAlgorithmECDSAP256,
+	v1alpha1.KeyAlgorithmECDSAP384,
+	v1alpha1.KeyAlgorithmRSA2048,
+	v1alpha1.KeyAlgorithmRSA4096,
+	v1alpha1.KeyAlgorithmEd25519,
+}
+
+// validateKeyAlgorithm checks that generateKey supports algorithm, without generating
+// a key
+func validateKeyAlgorithm(algorithm v1alpha1.KeyAlgorithm) error {
+	if !slices.Contains(keyAlgorithms, algorithm) {
+		return fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+	return nil
+}
+
+// generateKey generates a private key of the given algorithm, ECDSA P-256 if empty
+func generateKey(algorithm v1alpha1.KeyAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case "", v1alpha1.KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case v1alpha1.KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case v1alpha1.KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case v1alpha1.KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case v1alpha1.KeyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// privateKeyBlock PEM encodes a private key in the format most tools expect for its
+// type, i.e. SEC 1 for ECDSA, PKCS #1 for RSA and PKCS #8 for everything else
+func privateKeyBlock(key crypto.Signer) *pem.Block {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		b, _ := x509.MarshalECPrivateKey(k)
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: b}
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	default:
+		b, _ := x509.MarshalPKCS8PrivateKey(k)
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: b}
+	}
+}
+
+// parsePrivateKey parses a PEM encoded private key in any of the formats
+// privateKeyBlock writes
+func parsePrivateKey(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return parsePKCS8Key(keyPEM)
+	}
+}
+
+// leafKeyUsage returns the key usages of a leaf cert for key, only RSA keys can be
+// used for key encipherment
+func leafKeyUsage(key crypto.Signer) x509.KeyUsage {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature
+	}
+	return x509.KeyUsageDigitalSignature
+}
+
+// extKeyUsages maps usages to extended key usages, server auth if there are none
+func extKeyUsages(usages []v1alpha1.KeyUsage) []x509.ExtKeyUsage {
+	if len(usages) == 0 {
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+
+	var ext []x509.ExtKeyUsage
+	for _, u := range usages {
+		switch u {
+		case v1alpha1.UsageServerAuth:
+			ext = append(ext, x509.ExtKeyUsageServerAuth)
+		case v1alpha1.UsageClientAuth:
+			ext = append(ext, x509.ExtKeyUsageClientAuth)
+		}
+	}
+	return ext
+}
@@ -0,0 +1,303 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// keystoreFiles are the keys of the keystore and truststore each keystore format
+// writes to the backend Secret
+var keystoreFiles = map[v1alpha1.KeystoreFormat][2]string{
+	v1alpha1.KeystoreFormatPKCS12: {"keystore.p12", "truststore.p12"},
+	v1alpha1.KeystoreFormatJKS:    {"keystore.jks", "truststore.jks"},
+}
+
+// KeystorePasswordField is the field the password of the keystores is kept in, both
+// in the history and in the backend Secret
+const KeystorePasswordField = "keystore.password"
+
+// validateKeystores checks that spec only asks for keystore formats that can be written
+func validateKeystores(spec *v1alpha1.KeystoresSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if len(spec.Formats) == 0 {
+		return fmt.Errorf("keystores need at least one format")
+	}
+	for _, f := range spec.Formats {
+		if _, ok := keystoreFiles[f]; !ok {
+			return fmt.Errorf("unsupported keystore format %q", f)
+		}
+	}
+	return nil
+}
+
+// addKeystores adds the keystores spec asks for to content, the PEM encoded key.pem,
+// cert.pem and cacert.pem of a generation. The keystores are protected by the
+// generation's keystore.password, or by a new password for generations from before
+// spec.keystores was set.
+func addKeystores(spec v1alpha1.LeafCertificateSpec, content map[string]string) error {
+	if spec.Keystores == nil {
+		delete(content, KeystorePasswordField)
+		return nil
+	}
+
+	key, err := parsePrivateKey(content["key.pem"])
+	if err != nil {
+		return err
+	}
+	leaf, err := parseCerts(content["cert.pem"])
+	if err != nil || len(leaf) == 0 {
+		return fmt.Errorf("cannot parse cert.pem")
+	}
+	cas, err := parseCerts(content["cacert.pem"])
+	if err != nil {
+		return err
+	}
+	// the chain only has the CA that issued the cert, cacert.pem may also have the
+	// next CA during a CA rotation
+	var chain []*x509.Certificate
+	for _, ca := range cas {
+		if leaf[0].CheckSignatureFrom(ca) == nil {
+			chain = append(chain, ca)
+			break
+		}
+	}
+
+	password := content[KeystorePasswordField]
+	if password == "" {
+		password = generatePassword(spec.Keystores.Password)
+		content[KeystorePasswordField] = password
+	}
+	alias := spec.Keystores.Alias
+	if alias == "" {
+		alias = spec.AppName
+	}
+
+	for _, f := range spec.Keystores.Formats {
+		var keystore, truststore []byte
+		switch f {
+		case v1alpha1.KeystoreFormatPKCS12:
+			keystore, err = pkcs12.Modern.Encode(key, leaf[0], chain, password)
+			if err != nil {
+				return err
+			}
+			truststore, err = pkcs12.Modern.EncodeTrustStore(cas, password)
+		case v1alpha1.KeystoreFormatJKS:
+			keystore, err = jksKeystore(alias, key, append([]*x509.Certificate{leaf[0]}, chain...), password)
+			if err != nil {
+				return err
+			}
+			truststore, err = jksTruststore(cas, password)
+		}
+		if err != nil {
+			return err
+		}
+		content[keystoreFiles[f][0]] = string(keystore)
+		content[keystoreFiles[f][1]] = string(truststore)
+	}
+	return nil
+}
+
+// keystoresDrifted reports whether the keystores in the data of a backend Secret
+// differ from the ones spec asks for
+func keystoresDrifted(spec v1alpha1.LeafCertificateSpec, data map[string][]byte) bool {
+	var formats []v1alpha1.KeystoreFormat
+	if spec.Keystores != nil {
+		formats = spec.Keystores.Formats
+	}
+	for f, files := range keystoreFiles {
+		_, ok := data[files[0]]
+		if ok != slices.Contains(formats, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCerts parses every cert of a PEM bundle
+func parseCerts(bundle string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs, nil
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// The JKS format isn't specified anywhere but in the JDK's sun.security.provider
+// JavaKeyStore and KeyProtector, which these follow
+const (
+	jksMagic            = 0xfeedfeed
+	jksVersion          = 2
+	jksPrivateKeyEntry  = 1
+	jksTrustedCertEntry = 2
+	// jksWhitener is mixed into the integrity digest of every JKS file
+	jksWhitener = "Mighty Aphrodite"
+)
+
+// jksKeyProtectorOID identifies the proprietary algorithm JKS private keys are
+// encrypted with
+var jksKeyProtectorOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+
+// jksEncryptedPrivateKeyInfo is the PKCS #8 EncryptedPrivateKeyInfo a JKS private
+// key entry holds
+type jksEncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// jksWriter writes the big-endian fields of a JKS file
+type jksWriter struct {
+	bytes.Buffer
+}
+
+func (w *jksWriter) uint32(v uint32) {
+	w.Write(binary.BigEndian.AppendUint32(nil, v))
+}
+
+// utf writes s like Java's DataOutputStream.writeUTF, its length in bytes followed by
+// its bytes. Java's modified UTF-8 only differs from UTF-8 for NUL and characters
+// outside the BMP, which aliases don't have.
+func (w *jksWriter) utf(s string) {
+	w.Write(binary.BigEndian.AppendUint16(nil, uint16(len(s))))
+	w.WriteString(s)
+}
+
+func (w *jksWriter) cert(cert *x509.Certificate) {
+	w.utf("X.509")
+	w.uint32(uint32(len(cert.Raw)))
+	w.Write(cert.Raw)
+}
+
+// jksHeader starts a JKS file with count entries
+func jksHeader(count int) *jksWriter {
+	w := &jksWriter{}
+	w.uint32(jksMagic)
+	w.uint32(jksVersion)
+	w.uint32(uint32(count))
+	return w
+}
+
+// jksAlias returns alias the way the JDK stores it, JKS aliases are case-insensitive
+func jksAlias(alias string) string {
+	return strings.ToLower(alias)
+}
+
+// jksPassword returns password as the UTF-16BE bytes JKS digests and keys are
+// derived from
+func jksPassword(password string) []byte {
+	var b []byte
+	for _, c := range utf16.Encode([]rune(password)) {
+		b = binary.BigEndian.AppendUint16(b, c)
+	}
+	return b
+}
+
+// jksSign appends the integrity digest of the file written to w so far
+func jksSign(w *jksWriter, password string) []byte {
+	h := sha1.New()
+	h.Write(jksPassword(password))
+	h.Write([]byte(jksWhitener))
+	h.Write(w.Bytes())
+	w.Write(h.Sum(nil))
+	return w.Bytes()
+}
+
+// jksProtectKey encrypts a PKCS #8 private key the way the JDK's KeyProtector does,
+// XORing it with a keystream of chained SHA-1 digests of the password and a salt
+func jksProtectKey(key crypto.Signer, password string) ([]byte, error) {
+	plain, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, sha1.Size)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	passwd := jksPassword(password)
+	encrypted := make([]byte, len(plain))
+	digest := salt
+	for i := 0; i < len(plain); i += sha1.Size {
+		h := sha1.New()
+		h.Write(passwd)
+		h.Write(digest)
+		digest = h.Sum(nil)
+		for j := 0; j < sha1.Size && i+j < len(plain); j++ {
+			encrypted[i+j] = plain[i+j] ^ digest[j]
+		}
+	}
+
+	check := sha1.New()
+	check.Write(passwd)
+	check.Write(plain)
+
+	protected := append(append(slices.Clone(salt), encrypted...), check.Sum(nil)...)
+	return asn1.Marshal(jksEncryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: jksKeyProtectorOID, Parameters: asn1.NullRawValue},
+		EncryptedData: protected,
+	})
+}
+
+// jksKeystore returns a JKS keystore with key and its cert chain under alias
+func jksKeystore(alias string, key crypto.Signer, chain []*x509.Certificate, password string) ([]byte, error) {
+	protected, err := jksProtectKey(key, password)
+	if err != nil {
+		return nil, err
+	}
+
+	w := jksHeader(1)
+	w.uint32(jksPrivateKeyEntry)
+	w.utf(jksAlias(alias))
+	w.Write(binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixMilli())))
+	w.uint32(uint32(len(protected)))
+	w.Write(protected)
+	w.uint32(uint32(len(chain)))
+	for _, cert := range chain {
+		w.cert(cert)
+	}
+	return jksSign(w, password), nil
+}
+
+// jksTruststore returns a JKS truststore with certs as trusted cert entries, aliased
+// ca, ca-1, ca-2 and so on
+func jksTruststore(certs []*x509.Certificate, password string) ([]byte, error) {
+	w := jksHeader(len(certs))
+	for i, cert := range certs {
+		alias := "ca"
+		if i > 0 {
+			alias += "-" + strconv.Itoa(i)
+		}
+		w.uint32(jksTrustedCertEntry)
+		w.utf(alias)
+		w.Write(binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixMilli())))
+		w.cert(cert)
+	}
+	return jksSign(w, password), nil
+}
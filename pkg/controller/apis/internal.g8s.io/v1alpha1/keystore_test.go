@@ -0,0 +1,273 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// keystoreFixture returns the content of a generation whose cacert.pem has the CA
+// that issued cert.pem and, after it, the next CA of a CA rotation
+func keystoreFixture(t *testing.T) (map[string]string, *x509.Certificate, []*x509.Certificate) {
+	t.Helper()
+	now := time.Now()
+	ca, caKey, err := newCA(pkix.Name{CommonName: "ca"}, nil, v1alpha1.KeyAlgorithmECDSAP256, now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _, err := newCA(pkix.Name{CommonName: "next"}, nil, v1alpha1.KeyAlgorithmECDSAP256, now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := v1alpha1.LeafCertificateSpec{AppName: "web", SANs: []string{"web.example.com"}, KeyAlgorithm: v1alpha1.KeyAlgorithmRSA2048}
+	keyPEM, certPEM, err := issueLeaf(spec, time.Hour, ca, caKey, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := parseCerts(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := map[string]string{
+		"key.pem":             keyPEM,
+		"cert.pem":            certPEM,
+		"cacert.pem":          encodeCert(ca) + encodeCert(next),
+		KeystorePasswordField: "changeit",
+	}
+	return content, leaf[0], []*x509.Certificate{ca, next}
+}
+
+func keystoreSpec(formats ...v1alpha1.KeystoreFormat) v1alpha1.LeafCertificateSpec {
+	return v1alpha1.LeafCertificateSpec{
+		AppName:   "web",
+		Keystores: &v1alpha1.KeystoresSpec{Formats: formats, Alias: "Web-Server"},
+	}
+}
+
+func TestAddKeystoresPKCS12(t *testing.T) {
+	content, leaf, cas := keystoreFixture(t)
+	if err := addKeystores(keystoreSpec(v1alpha1.KeystoreFormatPKCS12), content); err != nil {
+		t.Fatal(err)
+	}
+
+	key, cert, chain, err := pkcs12.DecodeChain([]byte(content["keystore.p12"]), "changeit")
+	if err != nil {
+		t.Fatalf("DecodeChain() error = %v", err)
+	}
+	if !cert.Equal(leaf) {
+		t.Error("keystore.p12 cert differs from cert.pem")
+	}
+	if signer, ok := key.(crypto.Signer); !ok || !publicKeysEqual(signer.Public(), leaf.PublicKey) {
+		t.Error("keystore.p12 key doesn't match cert.pem")
+	}
+	if len(chain) != 1 || !chain[0].Equal(cas[0]) {
+		t.Errorf("keystore.p12 chain has %d certs, want only the issuing CA", len(chain))
+	}
+
+	trusted, err := pkcs12.DecodeTrustStore([]byte(content["truststore.p12"]), "changeit")
+	if err != nil {
+		t.Fatalf("DecodeTrustStore() error = %v", err)
+	}
+	if len(trusted) != len(cas) {
+		t.Fatalf("truststore.p12 has %d certs, want %d", len(trusted), len(cas))
+	}
+	for i := range cas {
+		if !trusted[i].Equal(cas[i]) {
+			t.Errorf("truststore.p12 cert %d differs from cacert.pem", i)
+		}
+	}
+
+	if _, _, _, err := pkcs12.DecodeChain([]byte(content["keystore.p12"]), "wrong"); err == nil {
+		t.Error("DecodeChain() with the wrong password succeeded")
+	}
+}
+
+func TestAddKeystoresJKS(t *testing.T) {
+	content, leaf, cas := keystoreFixture(t)
+	if err := addKeystores(keystoreSpec(v1alpha1.KeystoreFormatJKS), content); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := readJKS(t, []byte(content["keystore.jks"]), "changeit")
+	if len(entries) != 1 {
+		t.Fatalf("keystore.jks has %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.tag != 1 || e.alias != "web-server" {
+		t.Errorf("keystore.jks entry is %d %q, want private key entry %q", e.tag, e.alias, "web-server")
+	}
+	if len(e.chain) != 2 || !e.chain[0].Equal(leaf) || !e.chain[1].Equal(cas[0]) {
+		t.Errorf("keystore.jks chain has %d certs, want cert.pem and its issuing CA", len(e.chain))
+	}
+	key, err := x509.ParsePKCS8PrivateKey(jksRecoverKey(t, e.protected, "changeit"))
+	if err != nil {
+		t.Fatalf("cannot parse the recovered key: %v", err)
+	}
+	if signer, ok := key.(crypto.Signer); !ok || !publicKeysEqual(signer.Public(), leaf.PublicKey) {
+		t.Error("keystore.jks key doesn't match cert.pem")
+	}
+
+	entries = readJKS(t, []byte(content["truststore.jks"]), "changeit")
+	if len(entries) != len(cas) {
+		t.Fatalf("truststore.jks has %d entries, want %d", len(entries), len(cas))
+	}
+	for i, alias := range []string{"ca", "ca-1"} {
+		if entries[i].tag != 2 || entries[i].alias != alias || !entries[i].chain[0].Equal(cas[i]) {
+			t.Errorf("truststore.jks entry %d is %d %q, want trusted cert entry %q of cacert.pem", i, entries[i].tag, entries[i].alias, alias)
+		}
+	}
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
+
+// jksEntry is an entry of a JKS file, chain holding the cert of a trusted cert entry
+type jksEntry struct {
+	tag       uint32
+	alias     string
+	protected []byte
+	chain     []*x509.Certificate
+}
+
+// readJKS decodes a JKS file as the JDK's JavaKeyStore.engineLoad does, failing t
+// if it's malformed or its integrity digest doesn't match password
+func readJKS(t *testing.T, data []byte, password string) []jksEntry {
+	t.Helper()
+	if len(data) < 12+sha1.Size {
+		t.Fatalf("JKS file of %d bytes is too short", len(data))
+	}
+	body, digest := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	h := sha1.New()
+	for _, c := range utf16.Encode([]rune(password)) {
+		h.Write([]byte{byte(c >> 8), byte(c)})
+	}
+	h.Write([]byte("Mighty Aphrodite"))
+	h.Write(body)
+	if !bytes.Equal(h.Sum(nil), digest) {
+		t.Fatal("JKS integrity digest doesn't match")
+	}
+
+	r := bytes.NewReader(body)
+	read := func(v any) {
+		t.Helper()
+		if err := binary.Read(r, binary.BigEndian, v); err != nil {
+			t.Fatalf("truncated JKS file: %v", err)
+		}
+	}
+	bytesOf := func(n int) []byte {
+		t.Helper()
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			t.Fatalf("truncated JKS file: %v", err)
+		}
+		return b
+	}
+	utf := func() string {
+		var n uint16
+		read(&n)
+		return string(bytesOf(int(n)))
+	}
+	cert := func() *x509.Certificate {
+		t.Helper()
+		if typ := utf(); typ != "X.509" {
+			t.Fatalf("JKS cert type is %q, want X.509", typ)
+		}
+		var n uint32
+		read(&n)
+		c, err := x509.ParseCertificate(bytesOf(int(n)))
+		if err != nil {
+			t.Fatalf("cannot parse JKS cert: %v", err)
+		}
+		return c
+	}
+
+	var magic, version, count uint32
+	read(&magic)
+	read(&version)
+	read(&count)
+	if magic != 0xfeedfeed || version != 2 {
+		t.Fatalf("JKS header is %#x version %d, want 0xfeedfeed version 2", magic, version)
+	}
+
+	var entries []jksEntry
+	for i := uint32(0); i < count; i++ {
+		var e jksEntry
+		var date uint64
+		read(&e.tag)
+		e.alias = utf()
+		read(&date)
+		switch e.tag {
+		case 1:
+			var n uint32
+			read(&n)
+			e.protected = bytesOf(int(n))
+			read(&n)
+			for j := uint32(0); j < n; j++ {
+				e.chain = append(e.chain, cert())
+			}
+		case 2:
+			e.chain = []*x509.Certificate{cert()}
+		default:
+			t.Fatalf("unknown JKS entry tag %d", e.tag)
+		}
+		entries = append(entries, e)
+	}
+	if r.Len() != 0 {
+		t.Fatalf("%d bytes after the last JKS entry", r.Len())
+	}
+	return entries
+}
+
+// jksRecoverKey decrypts the PKCS #8 key of a JKS private key entry as the JDK's
+// KeyProtector.recover does, failing t if its check digest doesn't match password
+func jksRecoverKey(t *testing.T, protected []byte, password string) []byte {
+	t.Helper()
+	var info struct {
+		Algorithm     pkix.AlgorithmIdentifier
+		EncryptedData []byte
+	}
+	if _, err := asn1.Unmarshal(protected, &info); err != nil {
+		t.Fatalf("cannot parse EncryptedPrivateKeyInfo: %v", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}) {
+		t.Fatalf("key protected with %v, want the JDK KeyProtector", info.Algorithm.Algorithm)
+	}
+
+	var passwd []byte
+	for _, c := range utf16.Encode([]rune(password)) {
+		passwd = append(passwd, byte(c>>8), byte(c))
+	}
+	data := info.EncryptedData
+	salt, encrypted, check := data[:sha1.Size], data[sha1.Size:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+
+	plain := make([]byte, len(encrypted))
+	digest := salt
+	for i := range encrypted {
+		if i%sha1.Size == 0 {
+			d := sha1.Sum(append(append([]byte{}, passwd...), digest...))
+			digest = d[:]
+		}
+		plain[i] = encrypted[i] ^ digest[i%sha1.Size]
+	}
+
+	sum := sha1.Sum(append(passwd, plain...))
+	if !bytes.Equal(sum[:], check) {
+		t.Fatal("JKS key check digest doesn't match")
+	}
+	return plain
+}
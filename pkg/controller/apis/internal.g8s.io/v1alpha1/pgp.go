@@ -0,0 +1,208 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// defaultPGPBitSize is the size of rsa keys unless spec.bitSize says otherwise
+const defaultPGPBitSize = 4096
+
+// defaultPGPExpiry is how long keys are valid for unless spec.expiry says otherwise,
+// the same as gpg's default
+const defaultPGPExpiry = 2 * 365 * 24 * time.Hour
+
+// pgpConfig returns the packet.Config keys of algorithm are generated with
+func pgpConfig(algorithm v1alpha1.PGPKeyPairAlgorithm, bitSize int, now time.Time) *packet.Config {
+	config := &packet.Config{
+		Algorithm: packet.PubKeyAlgoEdDSA,
+		Curve:     packet.Curve25519,
+		Time:      func() time.Time { return now },
+	}
+	if algorithm == v1alpha1.PGPAlgorithmRSA {
+		config.Algorithm = packet.PubKeyAlgoRSA
+		config.RSABits = bitSize
+		if bitSize == 0 {
+			config.RSABits = defaultPGPBitSize
+		}
+	}
+	return config
+}
+
+// validatePGPKey checks that spec describes a key that can be generated
+func validatePGPKey(spec v1alpha1.PGPKeyPairSpec) error {
+	if spec.Name == "" && spec.Email == "" {
+		return fmt.Errorf("name or email must be set")
+	}
+	// the user ID is name (comment) <email>, which these would make ambiguous
+	for _, f := range []string{spec.Name, spec.Comment, spec.Email} {
+		if strings.ContainsAny(f, "()<>\x00") {
+			return fmt.Errorf("name, comment and email must not contain any of ()<>")
+		}
+	}
+
+	switch spec.Algorithm {
+	case "", v1alpha1.PGPAlgorithmEd25519:
+		if spec.BitSize != 0 {
+			return fmt.Errorf("bitSize is only supported for rsa keys")
+		}
+	case v1alpha1.PGPAlgorithmRSA:
+		if spec.BitSize != 0 && spec.BitSize < 2048 {
+			return fmt.Errorf("bitSize of rsa keys must be at least 2048, got %d", spec.BitSize)
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %q", spec.Algorithm)
+	}
+
+	if spec.Expiry != nil && spec.Expiry.Duration < 0 {
+		return fmt.Errorf("expiry must not be negative")
+	}
+	return nil
+}
+
+// newPGPKey generates a key with a primary key for signing and a subkey for
+// encryption that is valid for expiry from now, or doesn't expire if expiry is 0.
+// It returns the armored private key, encrypted with passphrase unless that's
+// empty, and the armored public key.
+func newPGPKey(spec v1alpha1.PGPKeyPairSpec, expiry time.Duration, passphrase string, now time.Time) (string, string, error) {
+	config := pgpConfig(spec.Algorithm, spec.BitSize, now)
+	config.KeyLifetimeSecs = uint32(expiry.Seconds())
+
+	entity, err := openpgp.NewEntity(spec.Name, spec.Comment, spec.Email, config)
+	if err != nil {
+		return "", "", err
+	}
+	return armorPGPKey(entity, passphrase, config)
+}
+
+// renewPGPKey extends the expiry of the armored private key privateKey to expiry
+// from now by signing its user IDs and subkeys again. The key and its fingerprint
+// stay the same, so anyone who has the key only needs to refresh it.
+func renewPGPKey(privateKey string, expiry time.Duration, passphrase string, now time.Time) (string, string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return "", "", err
+	}
+	if len(entities) != 1 || entities[0].PrivateKey == nil {
+		return "", "", fmt.Errorf("expected a single private key")
+	}
+	entity := entities[0]
+
+	if entity.PrivateKey.Encrypted {
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return "", "", err
+		}
+	}
+
+	config := &packet.Config{Time: func() time.Time { return now }}
+	var lifetime uint32
+	if expiry > 0 {
+		lifetime = uint32(now.Add(expiry).Sub(entity.PrimaryKey.CreationTime).Seconds())
+	}
+
+	for _, ident := range entity.Identities {
+		if ident.SelfSignature == nil {
+			continue
+		}
+		ident.SelfSignature.CreationTime = now
+		ident.SelfSignature.KeyLifetimeSecs = &lifetime
+		if err := ident.SelfSignature.SignUserId(ident.UserId.Id, entity.PrimaryKey, entity.PrivateKey, config); err != nil {
+			return "", "", err
+		}
+		ident.Signatures = []*packet.Signature{ident.SelfSignature}
+	}
+	// subkeys don't expire on their own, only along with the primary key, but their
+	// binding signatures are renewed as well so they're never older than it
+	for _, subkey := range entity.Subkeys {
+		subkey.Sig.CreationTime = now
+		if err := subkey.Sig.SignKey(subkey.PublicKey, entity.PrivateKey, config); err != nil {
+			return "", "", err
+		}
+	}
+
+	return armorPGPKey(entity, passphrase, config)
+}
+
+// armorPGPKey returns the private key of entity, encrypted with passphrase unless
+// that's empty, and its public key, both ASCII armored
+func armorPGPKey(entity *openpgp.Entity, passphrase string, config *packet.Config) (string, string, error) {
+	if passphrase != "" {
+		if err := entity.EncryptPrivateKeys([]byte(passphrase), config); err != nil {
+			return "", "", err
+		}
+	}
+
+	var private, public bytes.Buffer
+	w, err := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	// the self signatures are already current, signing them again would need the
+	// private key decrypted
+	if err := entity.SerializePrivateWithoutSigning(w, config); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	w, err = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := entity.Serialize(w); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	return private.String() + "\n", public.String() + "\n", nil
+}
+
+// readPGPPublicKey parses an armored public key
+func readPGPPublicKey(publicKey []byte) (*openpgp.Entity, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected a single public key, got %d", len(entities))
+	}
+	return entities[0], nil
+}
+
+// PGPFingerprint returns the fingerprint of an armored public key as gpg shows it,
+// or an empty string if it can't be parsed
+func PGPFingerprint(publicKey []byte) string {
+	entity, err := readPGPPublicKey(publicKey)
+	if err != nil {
+		return ""
+	}
+	return strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint))
+}
+
+// PGPExpiry returns when an armored public key expires, or nil if it doesn't
+func PGPExpiry(publicKey []byte) (*time.Time, error) {
+	entity, err := readPGPPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ident := entity.PrimaryIdentity()
+	if ident == nil || ident.SelfSignature == nil {
+		return nil, fmt.Errorf("key has no self-signed user ID")
+	}
+	lifetime := ident.SelfSignature.KeyLifetimeSecs
+	if lifetime == nil || *lifetime == 0 {
+		return nil, nil
+	}
+
+	expiry := entity.PrimaryKey.CreationTime.Add(time.Duration(*lifetime) * time.Second)
+	return &expiry, nil
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// defaultRandomBytes is the number of random bytes of a RandomSecret key unless the
+// spec says otherwise
+const defaultRandomBytes = 32
+
+// maxRandomBytes keeps a RandomSecret well below the size limit of a Secret
+const maxRandomBytes = 4096
+
+// defaultRandomSecretKey is the key of a RandomSecret's backend Secret if the spec
+// doesn't list any
+const defaultRandomSecretKey = "secret"
+
+// randomString returns n random bytes in the given encoding, or n random alphanumeric
+// characters, base64 if encoding is empty
+func randomString(n int, encoding v1alpha1.RandomSecretEncoding) (string, error) {
+	if encoding == v1alpha1.EncodingAlphanumeric {
+		settings := password.Settings{
+			Length:       n,
+			CharacterSet: defaultPasswordCharacterSet,
+		}
+		return settings.Generate()
+	}
+
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	switch encoding {
+	case "", v1alpha1.EncodingBase64:
+		return base64.StdEncoding.EncodeToString(b), nil
+	case v1alpha1.EncodingBase64URL:
+		return base64.URLEncoding.EncodeToString(b), nil
+	case v1alpha1.EncodingHex:
+		return hex.EncodeToString(b), nil
+	case v1alpha1.EncodingBase32:
+		return base32.StdEncoding.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+// validateRandomKey checks that a key of a RandomSecret can be stored and generated
+func validateRandomKey(key v1alpha1.RandomSecretKey) error {
+	if errs := validation.IsConfigMapKey(key.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid key name %q: %s", key.Name, errs[0])
+	}
+	if key.Name == metaField {
+		return fmt.Errorf("key name %q is reserved", key.Name)
+	}
+	if key.Bytes < 0 || key.Bytes > maxRandomBytes {
+		return fmt.Errorf("bytes of key %q must be between 1 and %d, got %d", key.Name, maxRandomBytes, key.Bytes)
+	}
+
+	switch key.Encoding {
+	case "", v1alpha1.EncodingHex, v1alpha1.EncodingBase64, v1alpha1.EncodingBase64URL, v1alpha1.EncodingBase32, v1alpha1.EncodingAlphanumeric:
+		return nil
+	default:
+		return fmt.Errorf("unsupported encoding %q of key %q", key.Encoding, key.Name)
+	}
+}
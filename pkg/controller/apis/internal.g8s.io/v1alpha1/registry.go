@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// dockerConfigEntry is the credential of a single registry in a dockerconfigjson
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+// dockerConfig is the content of the .dockerconfigjson key of a
+// kubernetes.io/dockerconfigjson Secret
+type dockerConfig struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// dockerConfigJSON returns a dockerconfigjson with the credential for server
+func dockerConfigJSON(server, username, password, email string) string {
+	config := dockerConfig{
+		Auths: map[string]dockerConfigEntry{
+			server: {
+				Username: username,
+				Password: password,
+				Email:    email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	}
+
+	b, _ := json.Marshal(config)
+	return string(b)
+}
+
+// htpasswdEntry returns a line of an htpasswd file with the bcrypt hash of password,
+// the only hash the registry's htpasswd auth accepts
+func htpasswdEntry(username, password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return username + ":" + string(hash) + "\n", nil
+}
+
+// validateRegistryCredential checks the parts of the spec the CRD schema can't
+func validateRegistryCredential(spec v1alpha1.RegistryCredentialSpec) error {
+	if spec.Server == "" {
+		return fmt.Errorf("server must not be empty")
+	}
+	if spec.Username == "" {
+		return fmt.Errorf("username must not be empty")
+	}
+	// both basic auth and htpasswd separate the username from the password with a colon
+	if strings.Contains(spec.Username, ":") {
+		return fmt.Errorf("username %q must not contain ':'", spec.Username)
+	}
+	return nil
+}
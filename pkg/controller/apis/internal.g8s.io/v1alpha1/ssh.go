@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/keygen"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// defaultSSHCertDuration is how long SSH certificates are valid for by default
+const defaultSSHCertDuration = 30 * 24 * time.Hour
+
+// sshCertBackdate is how far the start of an SSH certificate's validity is moved
+// into the past, so that hosts with a slightly late clock accept it right away
+const sshCertBackdate = 5 * time.Minute
+
+// defaultUserExtensions are the extensions ssh-keygen adds to user certificates
+var defaultUserExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// sshCurve maps the curve of an ecdsa SSHKeyPair to its elliptic.Curve, P-256 if empty
+func sshCurve(curve v1alpha1.SSHKeyPairCurve) (elliptic.Curve, error) {
+	switch curve {
+	case "", v1alpha1.CurveP256:
+		return elliptic.P256(), nil
+	case v1alpha1.CurveP384:
+		return elliptic.P384(), nil
+	case v1alpha1.CurveP521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", curve)
+	}
+}
+
+// validateSSHKey checks that keyType, curve and bitSize describe a key keygen can
+// generate
+func validateSSHKey(keyType v1alpha1.SSHKeyPairType, curve v1alpha1.SSHKeyPairCurve, bitSize int) error {
+	switch keyType {
+	case v1alpha1.RSA:
+		if bitSize != 0 && bitSize < 2048 {
+			return fmt.Errorf("bitSize of rsa keys must be at least 2048, got %d", bitSize)
+		}
+	case v1alpha1.Ed25519:
+	case v1alpha1.ECDSA:
+		if _, err := sshCurve(curve); err != nil {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported keyType %q", keyType)
+	}
+
+	if curve != "" {
+		return fmt.Errorf("curve is only supported for ecdsa keys")
+	}
+	return nil
+}
+
+// newSSHKey generates an SSH key of keyType, encrypted with passphrase unless it's
+// empty. RSA keys are 4096 bits unless bitSize is set.
+func newSSHKey(keyType v1alpha1.SSHKeyPairType, curve v1alpha1.SSHKeyPairCurve, bitSize int, passphrase string) (*keygen.KeyPair, error) {
+	opts := []keygen.Option{keygen.WithKeyType(keygen.KeyType(keyType))}
+	if bitSize > 0 {
+		// keygen would try to generate a zero bit key otherwise, instead of its default
+		opts = append(opts, keygen.WithBitSize(bitSize))
+	}
+	if keyType == v1alpha1.ECDSA {
+		ec, err := sshCurve(curve)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, keygen.WithEllipticCurve(ec))
+	}
+	if passphrase != "" {
+		opts = append(opts, keygen.WithPassphrase(passphrase))
+	}
+
+	return keygen.New("", opts...)
+}
+
+// authorizedKey returns the authorized_keys line of key, followed by comment if set
+func authorizedKey(key ssh.PublicKey, comment string) string {
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	if comment != "" {
+		line += " " + comment
+	}
+	return line
+}
+
+// SSHFingerprint returns the SHA256 fingerprint of an authorized_keys line, or an
+// empty string if it can't be parsed
+func SSHFingerprint(authorizedKey string) string {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(key)
+}
+
+// validateSSHCertificate checks the parts of an SSHCertificateSpec the CRD schema can't
+func validateSSHCertificate(spec *v1alpha1.SSHCertificateSpec) error {
+	if spec.CertificateAuthorityRef == "" {
+		return fmt.Errorf("certificate.certificateAuthorityRef is required")
+	}
+
+	switch spec.Type {
+	case "", v1alpha1.SSHUserCertificate, v1alpha1.SSHHostCertificate:
+	default:
+		return fmt.Errorf("unsupported certificate type %q", spec.Type)
+	}
+	return nil
+}
+
+// parseSSHSigner parses the unencrypted private key of an SSH CA
+func parseSSHSigner(keyPEM string) (ssh.Signer, error) {
+	return ssh.ParsePrivateKey([]byte(keyPEM))
+}
+
+// signSSHCertificate signs the public key in the authorized_keys line pub with ca,
+// as described by spec and valid for duration from now. It returns the certificate
+// as a line for ssh-cert.pub.
+func signSSHCertificate(spec *v1alpha1.SSHCertificateSpec, keyID, pub string, duration time.Duration, ca ssh.Signer, now time.Time) (string, error) {
+	key, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(pub))
+	if err != nil {
+		return "", err
+	}
+
+	certType := uint32(ssh.UserCert)
+	extensions := spec.Extensions
+	if spec.Type == v1alpha1.SSHHostCertificate {
+		certType = ssh.HostCert
+	} else if extensions == nil {
+		extensions = defaultUserExtensions
+	}
+	if spec.KeyID != "" {
+		keyID = spec.KeyID
+	}
+
+	serial := make([]byte, 8)
+	if _, err := rand.Read(serial); err != nil {
+		return "", err
+	}
+
+	cert := &ssh.Certificate{
+		Key:             key,
+		Serial:          binary.BigEndian.Uint64(serial),
+		CertType:        certType,
+		KeyId:           keyID,
+		ValidPrincipals: spec.Principals,
+		ValidAfter:      uint64(now.Add(-sshCertBackdate).Unix()),
+		ValidBefore:     uint64(now.Add(duration).Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: spec.CriticalOptions,
+			Extensions:      extensions,
+		},
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		return "", err
+	}
+
+	return authorizedKey(cert, comment), nil
+}
+
+// sshCertificateError is the error of an SSH certificate that can't be issued,
+// which unlike other errors of a rotation is reported in the SSHKeyPair's status
+type sshCertificateError struct {
+	err error
+}
+
+func (e sshCertificateError) Error() string {
+	return "cannot issue SSH certificate: " + e.err.Error()
+}
+
+// IsSSHCertificateError reports whether err is the error of an SSH certificate
+// that can't be issued
+func IsSSHCertificateError(err error) bool {
+	_, ok := err.(sshCertificateError)
+	return ok
+}
+
+// ParseSSHCertificate parses the OpenSSH certificate in an ssh-cert.pub line
+func ParseSSHCertificate(line []byte) (*ssh.Certificate, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a certificate", key.Type())
+	}
+	return cert, nil
+}
+
+// SSHCertificateSignedBy reports whether cert was signed by the CA whose public key
+// is the authorized_keys line caPub
+func SSHCertificateSignedBy(cert *ssh.Certificate, caPub []byte) bool {
+	ca, _, _, _, err := ssh.ParseAuthorizedKey(caPub)
+	if err != nil {
+		return false
+	}
+	return string(cert.SignatureKey.Marshal()) == string(ca.Marshal())
+}
+
+// sshTrustedKeys returns the CA public keys of every generation of a history, newest
+// first and one per line, in the format of an sshd TrustedUserCAKeys file. Hosts
+// that trust all of them keep accepting certificates signed by an earlier CA key
+// until those are reissued.
+func sshTrustedKeys(history map[string]string) []string {
+	var keys []string
+	for gen := 0; gen < Generations(history); gen++ {
+		pub, ok := history["ca.pub-"+strconv.Itoa(gen)]
+		if !ok || slices.Contains(keys, pub) {
+			continue
+		}
+		keys = append(keys, pub)
+	}
+	return keys
+}
@@ -0,0 +1,420 @@
+package v1alpha1
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+const (
+	// defaultCertDuration is how long certs are valid for unless spec.duration says otherwise
+	defaultCertDuration = time.Hour * 24 * 365
+
+	// defaultCADuration is how long a CertificateAuthority is valid for unless
+	// spec.duration says otherwise
+	defaultCADuration = defaultCertDuration * 10
+
+	// caDurationFactor is how many times longer than its certs the CA of a
+	// SelfSignedTLSBundle is valid for, so that it is reused for several renewals
+	// before it has to be replaced
+	caDurationFactor = 10
+
+	// defaultCAOverlap is how long each phase of a CA rotation lasts unless
+	// spec.caRotation.overlap says otherwise
+	defaultCAOverlap = time.Hour * 24
+)
+
+// kubernetesTLSKeys maps the fields of a TLS bundle's history to the keys of a
+// kubernetes.io/tls Secret
+var kubernetesTLSKeys = map[string]string{
+	"key.pem":    corev1.TLSPrivateKeyKey,
+	"cert.pem":   corev1.TLSCertKey,
+	"cacert.pem": "ca.crt",
+}
+
+// durationOrDefault returns d, or def if d isn't set
+func durationOrDefault(d *metav1.Duration, def time.Duration) time.Duration {
+	if d != nil && d.Duration > 0 {
+		return d.Duration
+	}
+	return def
+}
+
+// renewBefore returns how long before expiry a cert valid for duration is renewed, a
+// third of duration unless rb is set to something shorter than duration
+func renewBefore(rb *metav1.Duration, duration time.Duration) time.Duration {
+	if rb != nil && rb.Duration > 0 && rb.Duration < duration {
+		return rb.Duration
+	}
+	return duration / 3
+}
+
+// newSerial returns a random positive serial number for a new cert
+func newSerial() *big.Int {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64-1))
+	return new(big.Int).Add(serial, big.NewInt(1))
+}
+
+// newCA creates a self-signed CA cert and its key
+func newCA(subject pkix.Name, dnsNames []string, algorithm v1alpha1.KeyAlgorithm, notBefore, notAfter time.Time) (*x509.Certificate, crypto.Signer, error) {
+	key, err := generateKey(algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               subject,
+		DNSNames:              dnsNames,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+// parseCA parses a PEM encoded CA cert and its PKCS #8 key, or returns nils if
+// either is missing or malformed
+func parseCA(certPEM, keyPEM string) (*x509.Certificate, crypto.Signer) {
+	certBlock, _ := pem.Decode([]byte(certPEM))
+	keyBlock, _ := pem.Decode([]byte(keyPEM))
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil
+	}
+
+	return cert, signer
+}
+
+// issueLeaf generates a key and a cert for it as described by spec, valid for
+// duration from now and signed by caCert. It returns both PEM encoded.
+func issueLeaf(spec v1alpha1.LeafCertificateSpec, duration time.Duration, caCert *x509.Certificate, caKey crypto.Signer, now time.Time) (string, string, error) {
+	key, err := generateKey(spec.KeyAlgorithm)
+	if err != nil {
+		return "", "", err
+	}
+	ips, uris, err := parseSANs(spec)
+	if err != nil {
+		return "", "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber: newSerial(),
+		Subject:      leafSubject(spec),
+		DNSNames:     spec.SANs,
+		IPAddresses:  ips,
+		URIs:         uris,
+		NotBefore:    now,
+		NotAfter:     now.Add(duration),
+		KeyUsage:     leafKeyUsage(key),
+		ExtKeyUsage:  extKeyUsages(spec.Usages),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(pem.EncodeToMemory(privateKeyBlock(key))), string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+// encodeCert PEM encodes a cert
+func encodeCert(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// firstCert returns the first PEM block of bundle, i.e. the newest CA of a trust bundle
+func firstCert(bundle string) string {
+	block, _ := pem.Decode([]byte(bundle))
+	if block == nil {
+		return ""
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+// certFingerprint returns the hex encoded SHA-256 digest of a cert
+func certFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// encodePKCS8 PEM encodes a private key as PKCS #8
+func encodePKCS8(key crypto.Signer) string {
+	b, _ := x509.MarshalPKCS8PrivateKey(key)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}))
+}
+
+// validateLeaf checks the parts of spec the CRD schema can't
+func validateLeaf(spec v1alpha1.LeafCertificateSpec) error {
+	if err := validateKeyAlgorithm(spec.KeyAlgorithm); err != nil {
+		return err
+	}
+	if _, _, err := parseSANs(spec); err != nil {
+		return err
+	}
+	for _, u := range spec.Usages {
+		if u != v1alpha1.UsageServerAuth && u != v1alpha1.UsageClientAuth {
+			return fmt.Errorf("unsupported usage %q", u)
+		}
+	}
+	if err := validateKeystores(spec.Keystores); err != nil {
+		return err
+	}
+	return validateSubject(spec.Subject)
+}
+
+// SignerPrefix is the prefix of every CertificateSigningRequest signerName g8s signs for
+const SignerPrefix = "g8s.io/"
+
+// validateSignerName checks that a signerName is one g8s signs for
+func validateSignerName(signerName string) error {
+	if signerName != "" && (!strings.HasPrefix(signerName, SignerPrefix) || signerName == SignerPrefix) {
+		return fmt.Errorf("signerName %q is not prefixed with %s", signerName, SignerPrefix)
+	}
+	return nil
+}
+
+// csrKeyUsages maps the usages of a CertificateSigningRequest to x509 key usages
+var csrKeyUsages = map[certificatesv1.KeyUsage]x509.KeyUsage{
+	certificatesv1.UsageDigitalSignature: x509.KeyUsageDigitalSignature,
+	certificatesv1.UsageKeyEncipherment:  x509.KeyUsageKeyEncipherment,
+}
+
+// csrExtKeyUsages maps the usages of a CertificateSigningRequest to x509 extended
+// key usages
+var csrExtKeyUsages = map[certificatesv1.KeyUsage]x509.ExtKeyUsage{
+	certificatesv1.UsageServerAuth: x509.ExtKeyUsageServerAuth,
+	certificatesv1.UsageClientAuth: x509.ExtKeyUsageClientAuth,
+}
+
+// SignCertificateRequest issues the cert requested by csr, signed by the CA in
+// caCertPEM and caKeyPEM, and returns it PEM encoded. The cert is valid for
+// spec.expirationSeconds, or a year by default, but never longer than the CA.
+func SignCertificateRequest(caCertPEM, caKeyPEM string, csr *certificatesv1.CertificateSigningRequest) (string, error) {
+	caCert, caKey := parseCA(caCertPEM, caKeyPEM)
+	if caCert == nil {
+		return "", fmt.Errorf("cannot parse CA")
+	}
+
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return "", fmt.Errorf("no PEM encoded certificate request found")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	if err := request.CheckSignature(); err != nil {
+		return "", err
+	}
+
+	var keyUsage x509.KeyUsage
+	var extKeyUsages []x509.ExtKeyUsage
+	for _, u := range csr.Spec.Usages {
+		if ku, ok := csrKeyUsages[u]; ok {
+			keyUsage |= ku
+		} else if eku, ok := csrExtKeyUsages[u]; ok {
+			extKeyUsages = append(extKeyUsages, eku)
+		} else {
+			return "", fmt.Errorf("unsupported usage %q", u)
+		}
+	}
+
+	now := time.Now().UTC()
+	duration := defaultCertDuration
+	if csr.Spec.ExpirationSeconds != nil {
+		duration = time.Duration(*csr.Spec.ExpirationSeconds) * time.Second
+	}
+	notAfter := now.Add(duration)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:   newSerial(),
+		Subject:        request.Subject,
+		DNSNames:       request.DNSNames,
+		IPAddresses:    request.IPAddresses,
+		URIs:           request.URIs,
+		EmailAddresses: request.EmailAddresses,
+		NotBefore:      now,
+		NotAfter:       notAfter,
+		KeyUsage:       keyUsage,
+		ExtKeyUsage:    extKeyUsages,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, request.PublicKey, caKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+// validateSubject checks the subject fields the CRD schema can't
+func validateSubject(subject *v1alpha1.SubjectSpec) error {
+	if subject == nil {
+		return nil
+	}
+	for _, c := range subject.Countries {
+		if len(c) != 2 {
+			return fmt.Errorf("country %q is not a two-letter code", c)
+		}
+	}
+	return nil
+}
+
+// leafSubject returns the subject of a cert described by spec
+func leafSubject(spec v1alpha1.LeafCertificateSpec) pkix.Name {
+	return newSubject(spec.AppName, spec.Subject)
+}
+
+// newSubject returns a subject with the given common name and fields, the
+// organization defaults to g8s
+func newSubject(commonName string, fields *v1alpha1.SubjectSpec) pkix.Name {
+	subject := pkix.Name{
+		CommonName:   commonName,
+		Organization: []string{"g8s"},
+	}
+	if fields != nil {
+		if len(fields.Organizations) > 0 {
+			subject.Organization = fields.Organizations
+		}
+		subject.OrganizationalUnit = fields.OrganizationalUnits
+		subject.Country = fields.Countries
+	}
+	return subject
+}
+
+// parseSANs parses the IP and URI SANs of spec
+func parseSANs(spec v1alpha1.LeafCertificateSpec) ([]net.IP, []*url.URL, error) {
+	var ips []net.IP
+	for _, s := range spec.IPSANs {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, nil, fmt.Errorf("invalid IP SAN %q", s)
+		}
+		ips = append(ips, ip)
+	}
+
+	var uris []*url.URL
+	for _, s := range spec.URISANs {
+		uri, err := url.Parse(s)
+		if err != nil || uri.Scheme == "" {
+			return nil, nil, fmt.Errorf("invalid URI SAN %q", s)
+		}
+		uris = append(uris, uri)
+	}
+
+	return ips, uris, nil
+}
+
+// leafBackendContent adds the keystores of spec to content and renames its fields to
+// the keys of the output format of spec, it returns nil if the keystores can't be
+// written
+func leafBackendContent(spec v1alpha1.LeafCertificateSpec, content map[string]string) map[string]string {
+	if content == nil {
+		return nil
+	}
+	if err := addKeystores(spec, content); err != nil {
+		return nil
+	}
+	if spec.OutputFormat != v1alpha1.OutputFormatKubernetesTLS {
+		return content
+	}
+
+	tlsContent := make(map[string]string)
+	for f, v := range content {
+		tlsContent[leafBackendKey(spec, f)] = v
+	}
+	return tlsContent
+}
+
+// leafBackendKey returns the key a field of the history is stored under in the
+// backend Secret with the output format of spec
+func leafBackendKey(spec v1alpha1.LeafCertificateSpec, field string) string {
+	if spec.OutputFormat == v1alpha1.OutputFormatKubernetesTLS {
+		if key, ok := kubernetesTLSKeys[field]; ok {
+			return key
+		}
+	}
+	return field
+}
+
+// leafBackendFields returns generation gen of a history with the fields of a TLS
+// bundle's backend Secret, keystore.password being optional
+func leafBackendFields(history map[string]string, gen int) map[string]string {
+	content := generation(history, gen, "key.pem", "cert.pem", "cacert.pem")
+	if content == nil {
+		return nil
+	}
+	if optional := generation(history, gen, KeystorePasswordField); optional != nil {
+		content[KeystorePasswordField] = optional[KeystorePasswordField]
+	}
+	return content
+}
+
+// leafSecretType returns the type of a backend Secret with the output format of spec,
+// pemType being the type used for the pem format
+func leafSecretType(spec v1alpha1.LeafCertificateSpec, pemType corev1.SecretType) corev1.SecretType {
+	if spec.OutputFormat == v1alpha1.OutputFormatKubernetesTLS {
+		return corev1.SecretTypeTLS
+	}
+	return pemType
+}
+
+// certManagerAnnotations returns cert-manager's Secret annotations for a cert
+// described by spec, or nil if spec doesn't ask for them
+func certManagerAnnotations(spec v1alpha1.LeafCertificateSpec, name, issuerName, issuerKind string) map[string]string {
+	if !spec.CertManagerAnnotations {
+		return nil
+	}
+
+	return map[string]string{
+		"cert-manager.io/certificate-name": name,
+		"cert-manager.io/common-name":      spec.AppName,
+		"cert-manager.io/alt-names":        strings.Join(spec.SANs, ","),
+		"cert-manager.io/ip-sans":          strings.Join(spec.IPSANs, ","),
+		"cert-manager.io/uri-sans":         strings.Join(spec.URISANs, ","),
+		"cert-manager.io/issuer-name":      issuerName,
+		"cert-manager.io/issuer-kind":      issuerKind,
+		"cert-manager.io/issuer-group":     v1alpha1.GroupName,
+	}
+}
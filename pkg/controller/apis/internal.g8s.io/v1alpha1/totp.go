@@ -0,0 +1,142 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"image/png"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// totpSecretSize is the size of a TOTP seed in bytes, the 160 bits RFC 4226 recommends
+const totpSecretSize = 20
+
+// totpQRCodeSize is the width and height in pixels of the QR code of a TOTPSeed
+const totpQRCodeSize = 256
+
+// totpSkew is how many periods before and after the current one VerifyTOTP accepts
+// codes from, to allow for clock drift between the authenticator and the verifier
+const totpSkew = 1
+
+// validateTOTPSeed checks that spec describes a seed authenticator apps can enroll
+func validateTOTPSeed(spec v1alpha1.TOTPSeedSpec) error {
+	if spec.Issuer == "" || spec.AccountName == "" {
+		return fmt.Errorf("issuer and accountName are required")
+	}
+	// the label of the otpauth URI is issuer:accountName
+	if strings.Contains(spec.Issuer, ":") {
+		return fmt.Errorf("issuer must not contain ':'")
+	}
+	if spec.Digits != 0 && spec.Digits != 6 && spec.Digits != 8 {
+		return fmt.Errorf("digits must be 6 or 8, got %d", spec.Digits)
+	}
+	if spec.Period < 0 {
+		return fmt.Errorf("period must not be negative, got %d", spec.Period)
+	}
+
+	switch spec.Algorithm {
+	case "", v1alpha1.TOTPAlgorithmSHA1, v1alpha1.TOTPAlgorithmSHA256, v1alpha1.TOTPAlgorithmSHA512:
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q", spec.Algorithm)
+	}
+}
+
+// newTOTPSecret generates a seed, base32 encoded without padding like authenticator
+// apps expect it
+func newTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// totpKey returns the otpauth key of a seed with the parameters of spec, which the
+// defaults of pquerna/otp fill in for digits, period and algorithm
+func totpKey(spec v1alpha1.TOTPSeedSpec, secret string) (*otp.Key, error) {
+	b, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := otp.AlgorithmSHA1
+	switch spec.Algorithm {
+	case v1alpha1.TOTPAlgorithmSHA256:
+		algorithm = otp.AlgorithmSHA256
+	case v1alpha1.TOTPAlgorithmSHA512:
+		algorithm = otp.AlgorithmSHA512
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      spec.Issuer,
+		AccountName: spec.AccountName,
+		Period:      uint(spec.Period),
+		Secret:      b,
+		Digits:      otp.Digits(spec.Digits),
+		Algorithm:   algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// pquerna/otp escapes query values like path segments, which leaves '&', '=' and
+	// '+' of the issuer as they are, so the query is encoded again. Spaces stay %20,
+	// which Google Authenticator needs.
+	u, err := url.Parse(key.URL())
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{}
+	v.Set("secret", key.Secret())
+	v.Set("issuer", spec.Issuer)
+	v.Set("period", strconv.FormatUint(key.Period(), 10))
+	v.Set("algorithm", key.Algorithm().String())
+	v.Set("digits", key.Digits().String())
+	u.RawQuery = strings.ReplaceAll(v.Encode(), "+", "%20")
+	return otp.NewKeyFromURL(u.String())
+}
+
+// totpQRCode renders the otpauth URI of key as a PNG QR code for authenticator apps
+// to scan
+func totpQRCode(key *otp.Key) (string, error) {
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// VerifyTOTP checks a code submitted at t against the otpauth URI in the backend
+// Secret of a TOTPSeed, which carries the seed along with its digits, period and
+// algorithm. Codes of the periods right before and after t are accepted as well.
+func VerifyTOTP(uri, code string, t time.Time) (bool, error) {
+	key, err := otp.NewKeyFromURL(uri)
+	if err != nil {
+		return false, err
+	}
+	if key.Type() != "totp" {
+		return false, fmt.Errorf("not a TOTP URI: %s", key.Type())
+	}
+
+	return totp.ValidateCustom(code, key.Secret(), t.UTC(), totp.ValidateOpts{
+		Period:    uint(key.Period()),
+		Skew:      totpSkew,
+		Digits:    key.Digits(),
+		Algorithm: key.Algorithm(),
+	})
+}
@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+func TestTOTPRFC6238Vectors(t *testing.T) {
+	// the seeds and codes of RFC 6238 appendix B, whose seeds are the ASCII digits
+	// repeated to the size of each algorithm's digest
+	seeds := map[v1alpha1.TOTPAlgorithm]string{
+		v1alpha1.TOTPAlgorithmSHA1:   "12345678901234567890",
+		v1alpha1.TOTPAlgorithmSHA256: "12345678901234567890123456789012",
+		v1alpha1.TOTPAlgorithmSHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	tests := []struct {
+		time                 int64
+		sha1, sha256, sha512 string
+	}{
+		{59, "94287082", "46119246", "90693936"},
+		{1111111109, "07081804", "68084774", "25091201"},
+		{1111111111, "14050471", "67062674", "99943326"},
+		{1234567890, "89005924", "91819424", "93441116"},
+		{2000000000, "69279037", "90698825", "38618901"},
+		{20000000000, "65353130", "77737706", "47863826"},
+	}
+
+	for _, tt := range tests {
+		for algorithm, want := range map[v1alpha1.TOTPAlgorithm]string{
+			v1alpha1.TOTPAlgorithmSHA1:   tt.sha1,
+			v1alpha1.TOTPAlgorithmSHA256: tt.sha256,
+			v1alpha1.TOTPAlgorithmSHA512: tt.sha512,
+		} {
+			spec := v1alpha1.TOTPSeedSpec{Issuer: "g8s", AccountName: "rfc6238", Digits: 8, Period: 30, Algorithm: algorithm}
+			secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(seeds[algorithm]))
+			key, err := totpKey(spec, secret)
+			if err != nil {
+				t.Fatalf("totpKey(%s) error = %v", algorithm, err)
+			}
+			at := time.Unix(tt.time, 0)
+
+			// the code generated from what the URI carries, so that a lost parameter
+			// shows up as a wrong code
+			parsed, err := otp.NewKeyFromURL(key.URL())
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := totp.GenerateCodeCustom(parsed.Secret(), at, totp.ValidateOpts{
+				Period:    uint(parsed.Period()),
+				Digits:    parsed.Digits(),
+				Algorithm: parsed.Algorithm(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("%s code at %d = %s, want %s", algorithm, tt.time, got, want)
+			}
+
+			ok, err := VerifyTOTP(key.URL(), want, at)
+			if err != nil || !ok {
+				t.Errorf("VerifyTOTP(%s, %s, %d) = %v, %v, want true", algorithm, want, tt.time, ok, err)
+			}
+			// beyond the skew of one period either side
+			ok, err = VerifyTOTP(key.URL(), want, at.Add(3*30*time.Second))
+			if err != nil || ok {
+				t.Errorf("VerifyTOTP(%s, %s, %d+90s) = %v, %v, want false", algorithm, want, tt.time, ok, err)
+			}
+		}
+	}
+}
+
+func TestTOTPKeyURI(t *testing.T) {
+	spec := v1alpha1.TOTPSeedSpec{
+		Issuer:      "Acme & Co/Ops",
+		AccountName: "jane doe+2fa@example.com",
+		Digits:      8,
+		Period:      60,
+		Algorithm:   v1alpha1.TOTPAlgorithmSHA256,
+	}
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := totpKey(spec, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri := key.URL()
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// authenticator apps read a + in the query as a space, spaces have to be %20
+	if strings.ContainsAny(u.RawQuery, " +") {
+		t.Errorf("query %q has spaces or pluses unescaped", u.RawQuery)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Errorf("URI %q isn't an otpauth://totp URI", uri)
+	}
+	if want := "/" + spec.Issuer + ":" + spec.AccountName; u.Path != want {
+		t.Errorf("label = %q, want %q", u.Path, want)
+	}
+	q := u.Query()
+	want := map[string]string{
+		"issuer":    spec.Issuer,
+		"secret":    secret,
+		"digits":    "8",
+		"period":    "60",
+		"algorithm": "SHA256",
+	}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+		}
+	}
+
+	parsed, err := otp.NewKeyFromURL(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Issuer() != spec.Issuer || parsed.AccountName() != spec.AccountName {
+		t.Errorf("parsed issuer and account = %q, %q, want %q, %q", parsed.Issuer(), parsed.AccountName(), spec.Issuer, spec.AccountName)
+	}
+}
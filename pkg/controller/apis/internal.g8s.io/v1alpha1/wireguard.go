@@ -0,0 +1,127 @@
+package v1alpha1
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// WireGuardPeer is what the wg0.conf of a WireGuardKeyPair needs to know about one
+// of the WireGuardKeyPairs it references
+type WireGuardPeer struct {
+	Name         string
+	PublicKey    string
+	PresharedKey string
+	Endpoint     string
+	AllowedIPs   []string
+}
+
+// newWireGuardKey generates a Curve25519 private key the way wg genkey does and
+// returns it along with its public key, both base64 encoded
+func newWireGuardKey() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	return wireGuardKeyFromBytes(b)
+}
+
+// wireGuardKeyFromBytes clamps 32 random bytes into a Curve25519 private key and
+// returns it along with its public key, both base64 encoded
+func wireGuardKeyFromBytes(b []byte) (string, string, error) {
+	// clamp the key, X25519 ignores these bits but wg genkey clears them anyway
+	b[0] &= 248
+	b[31] = (b[31] & 127) | 64
+
+	key, err := ecdh.X25519().NewPrivateKey(b)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(key.Bytes()), base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// newWireGuardPresharedKey generates a preshared key the way wg genpsk does
+func newWireGuardPresharedKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// wireGuardPairPresharedKey returns the preshared key both ends of the tunnel
+// between two WireGuardKeyPairs use. Each of them may have its own, so it's the one
+// of the first of the two by name that has one, which both ends agree on.
+func wireGuardPairPresharedKey(name, psk string, peer WireGuardPeer) string {
+	if (name < peer.Name && psk != "") || peer.PresharedKey == "" {
+		return psk
+	}
+	return peer.PresharedKey
+}
+
+// wireGuardConfig renders a wg0.conf with the interface of the key pair name and a
+// [Peer] section for each of peers
+func wireGuardConfig(spec v1alpha1.WireGuardKeyPairSpec, name, privateKey, psk string, peers []WireGuardPeer) string {
+	var b strings.Builder
+	b.WriteString("[Interface]\n")
+	b.WriteString("PrivateKey = " + privateKey + "\n")
+	if spec.Address != "" {
+		b.WriteString("Address = " + spec.Address + "\n")
+	}
+	if spec.ListenPort != 0 {
+		b.WriteString("ListenPort = " + strconv.Itoa(int(spec.ListenPort)) + "\n")
+	}
+
+	for _, peer := range peers {
+		b.WriteString("\n[Peer]\n")
+		b.WriteString("# " + peer.Name + "\n")
+		b.WriteString("PublicKey = " + peer.PublicKey + "\n")
+		if pairPSK := wireGuardPairPresharedKey(name, psk, peer); pairPSK != "" {
+			b.WriteString("PresharedKey = " + pairPSK + "\n")
+		}
+		if len(peer.AllowedIPs) > 0 {
+			b.WriteString("AllowedIPs = " + strings.Join(peer.AllowedIPs, ", ") + "\n")
+		}
+		if peer.Endpoint != "" {
+			b.WriteString("Endpoint = " + peer.Endpoint + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// validateWireGuardKeyPair checks the parts of the spec the CRD schema can't
+func validateWireGuardKeyPair(name string, spec v1alpha1.WireGuardKeyPairSpec) error {
+	if spec.Address != "" {
+		if _, err := netip.ParsePrefix(spec.Address); err != nil {
+			return fmt.Errorf("invalid address %q: %s", spec.Address, err.Error())
+		}
+	}
+	if spec.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(spec.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint %q: %s", spec.Endpoint, err.Error())
+		}
+	}
+	for _, ip := range spec.AllowedIPs {
+		if _, err := netip.ParsePrefix(ip); err != nil {
+			return fmt.Errorf("invalid allowedIPs entry %q: %s", ip, err.Error())
+		}
+	}
+	for i, peer := range spec.Peers {
+		if peer == name {
+			return fmt.Errorf("a WireGuardKeyPair can't be its own peer")
+		}
+		if slices.Contains(spec.Peers[:i], peer) {
+			return fmt.Errorf("duplicate peer %q", peer)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestWireGuardKeyFromBytes(t *testing.T) {
+	// Alice's key pair of RFC 7748 section 6.1. Its private key isn't clamped, so the
+	// one returned has the low three bits of the first byte and the top bit of the
+	// last cleared and the second-to-top bit set, as wg genkey writes it.
+	b, err := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPrivate := "cAdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LGo="
+	wantPublic := "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="
+
+	private, public, err := wireGuardKeyFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if private != wantPrivate {
+		t.Errorf("private key = %s, want %s", private, wantPrivate)
+	}
+	if public != wantPublic {
+		t.Errorf("public key = %s, want %s", public, wantPublic)
+	}
+}
+
+func TestNewWireGuardKeyClamped(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		private, _, err := newWireGuardKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := base64.StdEncoding.DecodeString(private)
+		if err != nil || len(b) != 32 {
+			t.Fatalf("private key %q isn't 32 base64 encoded bytes", private)
+		}
+		if b[0]&7 != 0 || b[31]&128 != 0 || b[31]&64 == 0 {
+			t.Fatalf("private key %x isn't clamped", b)
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runCertificateWorker is a long-running function that will continually call the
+// processNextCertificateWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runCertificateWorker(ctx context.Context) {
+	for c.processNextCertificateWorkItem(ctx) {
+	}
+}
+
+// processNextCertificateWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the certificateSyncHandler.
+func (c *Controller) processNextCertificateWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.certificateWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.certificateWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.certificateWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.certificateWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the certificateSyncHandler, passing it the namespace/name string of the
+		// Certificate resource to be synced.
+		if err := c.certificateSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.certificateWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.certificateWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// certificateSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Certificate resource
+// with the current status of the resource.
+func (c *Controller) certificateSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the Certificate resource with this namespace/name
+	certificateFromLister, err := c.certificateLister.Certificates(namespace).Get(name)
+	if err != nil {
+		// The Certificate resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("Certificate '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	certificate := certificateFromLister.DeepCopy()
+
+	backendName := "certificate-" + certificate.ObjectMeta.Name
+	historyName := "certificate-" + certificate.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(certificate.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, certificate.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sCertificate := internalv1alpha1.NewCertificate(certificate)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sCertificate.Validate(); err != nil {
+		c.recorder.Event(certificate, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// The cert is signed with the key in the backend Secret of the CertificateAuthority
+	// it references. Until that exists there's nothing to do, the CertificateAuthority
+	// enqueues its Certificates whenever it changes.
+	caName := certificate.Spec.CertificateAuthorityRef
+	_, err = c.certificateAuthorityLister.CertificateAuthorities(certificate.Namespace).Get(caName)
+	var caBackend *corev1.Secret
+	if err == nil {
+		caBackend, err = c.secretLister.Secrets(certificate.Namespace).Get("certificateauthority-" + caName)
+	}
+	if err == nil {
+		err = g8sCertificate.SetCertificateAuthority(caBackend.Data)
+	}
+	if err != nil {
+		c.recorder.Eventf(certificate, corev1.EventTypeWarning, ErrIssuerNotReady, MessageIssuerNotReady, caName, err.Error())
+		logger.V(4).Info("CertificateAuthority not ready", "certificateAuthority", caName, "err", err.Error())
+		return nil
+	}
+	caCertPEM := string(caBackend.Data["cacert.pem"])
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sCertificate.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(certificate, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sCertificate.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(certificate.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sCertificate, backendContent, g8sCertificate.SecretType()), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(certificate.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sCertificate, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sCertificate.BackendContent(internalv1alpha1.StringData(history.Data), certificate.Status.LiveGeneration)
+		if content == nil {
+			content = g8sCertificate.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(certificate.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sCertificate, content, g8sCertificate.SecretType()), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["key.pem-0"] = string(backend.Data[g8sCertificate.BackendKey("key.pem")])
+		content["cert.pem-0"] = string(backend.Data[g8sCertificate.BackendKey("cert.pem")])
+		content["cacert.pem-0"] = string(backend.Data[g8sCertificate.BackendKey("cacert.pem")])
+		if password, ok := backend.Data[internalv1alpha1.KeystorePasswordField]; ok {
+			content["keystore.password-0"] = string(password)
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(certificate, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(certificate.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sCertificate, content), metav1.CreateOptions{})
+		certificate.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this Certificate resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, certificate) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(certificate, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, certificate) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(certificate, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Recreate the backend Secret if the output format, keystores or cert-manager
+	// annotations changed
+	if backend.Type != g8sCertificate.SecretType() || g8sCertificate.KeystoresDrifted(backend.Data) || annotationsDrifted(backend, "cert-manager.io/", g8sCertificate.BackendAnnotations()) {
+		content := g8sCertificate.BackendContent(internalv1alpha1.StringData(history.Data), certificate.Status.LiveGeneration)
+		if content != nil {
+			logger.V(4).Info("Recreate backend Secret resource in current output format", "format", certificate.Spec.OutputFormat)
+			backend, err = c.replaceBackend(ctx, g8sCertificate, content, g8sCertificate.SecretType())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the Certificate ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     certificate,
+		g8s:        g8sCertificate,
+		workqueue:  c.certificateWorkqueue,
+		rotation:   certificate.Spec.Rotation,
+		history:    certificate.Spec.History,
+		status:     &certificate.Status.RotationStatus,
+		secretType: g8sCertificate.SecretType(),
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().Certificates(certificate.Namespace).UpdateStatus(ctx, certificate, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*certificate = *updated
+			return nil
+		},
+	}
+	rotation := c.checkRotation(rotated, backend)
+
+	// Reissue the cert in the backend Secret once it's within renewBefore of expiring,
+	// right away if it can't be parsed, and whenever the CertificateAuthority moved on
+	// to a new CA
+	renew := false
+	cert, err := parseCertificate(backend.Data[g8sCertificate.BackendKey("cert.pem")])
+	if err != nil {
+		logger.V(4).Info("Cannot parse cert in backend Secret, reissuing", "err", err.Error())
+		renew = true
+	} else if !renewalTime(cert, g8sCertificate.RenewBefore()).After(time.Now()) {
+		renew = true
+	}
+	reissue := string(backend.Data[g8sCertificate.BackendKey("cacert.pem")]) != caCertPEM
+
+	var renewCert *renewal
+	if renew {
+		renewCert = &renewal{
+			generate: func() (map[string]string, error) { return g8sCertificate.Rotate() },
+			message:  MessageCertificateRenewed,
+		}
+	} else if reissue {
+		renewCert = &renewal{
+			generate: func() (map[string]string, error) { return g8sCertificate.Rotate() },
+			message:  MessageCertificateReissued,
+			args:     []any{caName},
+		}
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, renewCert)
+	if err != nil {
+		return err
+	}
+
+	// Record the validity of the cert now in the backend Secret and come back when
+	// it's due for renewal
+	certificate.Status.NotBefore = nil
+	certificate.Status.NotAfter = nil
+	certificate.Status.RenewalTime = nil
+	if cert, err := parseCertificate(backend.Data[g8sCertificate.BackendKey("cert.pem")]); err == nil {
+		renewal := renewalTime(cert, g8sCertificate.RenewBefore())
+		certificate.Status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+		certificate.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+		certificate.Status.RenewalTime = &metav1.Time{Time: renewal}
+		c.certificateWorkqueue.AddAfter(key, time.Until(renewal))
+	}
+
+	// Finally, we update the status block of the Certificate resource to reflect the
+	// current state of the world
+	err = c.updateCertificateStatus(certificate)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(certificate, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+func (c *Controller) updateCertificateStatus(certificate *g8sv1alpha1.Certificate) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	certificateCopy := certificate.DeepCopy()
+	certificateCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the Certificate resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().Certificates(certificate.Namespace).UpdateStatus(context.TODO(), certificateCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueCertificate takes a Certificate resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other than Certificate.
+func (c *Controller) enqueueCertificate(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.certificateWorkqueue.Add(key)
+}
+
+// handleCertificateObject will take any resource implementing metav1.Object and attempt
+// to find the Certificate resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that Certificate resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleCertificateObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a Certificate, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "Certificate" {
+			return
+		}
+
+		certificate, err := c.certificateLister.Certificates(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "certificate", ownerRef.Name)
+			return
+		}
+
+		c.enqueueCertificate(certificate)
+		return
+	}
+}
+
+// Set up an event handler for when Certificate and/or their backend and history Secret resources change
+func (c *Controller) setCertificateInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.certificateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueCertificate,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueCertificate(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			cert, ok := obj.(*g8sv1alpha1.Certificate)
+			if !ok {
+				logger.Error(nil, "obj is not a Certificate")
+			}
+			c.recorder.Event(cert, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when Certificate backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a Certificate resource then the handler will enqueue that Certificate resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleCertificateObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleCertificateObject(new)
+		},
+		DeleteFunc: c.handleCertificateObject,
+	})
+
+	// Certificates are signed by the CA of the CertificateAuthority they reference, so
+	// they have to be looked at again whenever it changes
+	c.certificateAuthorityInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueCertificatesIssuedBy,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueCertificatesIssuedBy(new)
+		},
+	})
+}
+
+// enqueueCertificatesIssuedBy enqueues every Certificate that references the
+// CertificateAuthority obj.
+func (c *Controller) enqueueCertificatesIssuedBy(obj interface{}) {
+	ca, ok := obj.(*g8sv1alpha1.CertificateAuthority)
+	if !ok {
+		return
+	}
+
+	certificates, err := c.certificateLister.Certificates(ca.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, certificate := range certificates {
+		if certificate.Spec.CertificateAuthorityRef == ca.Name {
+			c.enqueueCertificate(certificate)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runCertificateAuthorityWorker is a long-running function that will continually call the
+// processNextCertificateAuthorityWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runCertificateAuthorityWorker(ctx context.Context) {
+	for c.processNextCertificateAuthorityWorkItem(ctx) {
+	}
+}
+
+// processNextCertificateAuthorityWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the certificateAuthoritySyncHandler.
+func (c *Controller) processNextCertificateAuthorityWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.certificateAuthorityWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.certificateAuthorityWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.certificateAuthorityWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.certificateAuthorityWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the certificateAuthoritySyncHandler, passing it the namespace/name string of the
+		// CertificateAuthority resource to be synced.
+		if err := c.certificateAuthoritySyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.certificateAuthorityWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.certificateAuthorityWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// certificateAuthoritySyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the CertificateAuthority resource
+// with the current status of the resource.
+func (c *Controller) certificateAuthoritySyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the CertificateAuthority resource with this namespace/name
+	certificateAuthorityFromLister, err := c.certificateAuthorityLister.CertificateAuthorities(namespace).Get(name)
+	if err != nil {
+		// The CertificateAuthority resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("CertificateAuthority '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	certificateAuthority := certificateAuthorityFromLister.DeepCopy()
+
+	backendName := "certificateauthority-" + certificateAuthority.ObjectMeta.Name
+	historyName := "certificateauthority-" + certificateAuthority.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(certificateAuthority.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, certificateAuthority.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sCertificateAuthority := internalv1alpha1.NewCertificateAuthority(certificateAuthority)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sCertificateAuthority.Validate(); err != nil {
+		c.recorder.Event(certificateAuthority, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sCertificateAuthority.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(certificateAuthority, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sCertificateAuthority.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(certificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sCertificateAuthority, backendContent, certificateAuthoritySecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(certificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sCertificateAuthority, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sCertificateAuthority.BackendContent(internalv1alpha1.StringData(history.Data), certificateAuthority.Status.LiveGeneration)
+		if content == nil {
+			content = g8sCertificateAuthority.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(certificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sCertificateAuthority, content, certificateAuthoritySecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["cacert.pem-0"] = string(backend.Data["cacert.pem"])
+		content["cakey.pem-0"] = string(backend.Data["cakey.pem"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(certificateAuthority, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(certificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sCertificateAuthority, content), metav1.CreateOptions{})
+		certificateAuthority.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this CertificateAuthority resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, certificateAuthority) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(certificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, certificateAuthority) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(certificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the CertificateAuthority ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     certificateAuthority,
+		g8s:        g8sCertificateAuthority,
+		workqueue:  c.certificateAuthorityWorkqueue,
+		rotation:   certificateAuthority.Spec.Rotation,
+		history:    certificateAuthority.Spec.History,
+		status:     &certificateAuthority.Status.RotationStatus,
+		secretType: certificateAuthoritySecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().CertificateAuthorities(certificateAuthority.Namespace).UpdateStatus(ctx, certificateAuthority, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*certificateAuthority = *updated
+			return nil
+		},
+	}
+	rotation := c.checkRotation(rotated, backend)
+
+	// Regenerate the CA once it's within renewBefore of expiring, or right away if it
+	// can't be parsed
+	renew := false
+	cert, err := parseCertificate(backend.Data["cacert.pem"])
+	if err != nil {
+		logger.V(4).Info("Cannot parse CA cert in backend Secret, regenerating", "err", err.Error())
+		renew = true
+	} else if !renewalTime(cert, g8sCertificateAuthority.RenewBefore()).After(time.Now()) {
+		renew = true
+	}
+
+	var renewCA *renewal
+	if renew {
+		renewCA = &renewal{
+			generate: func() (map[string]string, error) { return g8sCertificateAuthority.Rotate() },
+			message:  MessageCertificateRenewed,
+		}
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, renewCA)
+	if err != nil {
+		return err
+	}
+
+	// Publish every CA cert that certs may still be signed by
+	err = c.syncTrustBundle(ctx, certificateAuthority, g8sCertificateAuthority, history)
+	if err != nil {
+		return err
+	}
+
+	// Record the validity of the CA cert now in the backend Secret and come back when
+	// it's due for renewal
+	certificateAuthority.Status.NotBefore = nil
+	certificateAuthority.Status.NotAfter = nil
+	certificateAuthority.Status.RenewalTime = nil
+	if cert, err := parseCertificate(backend.Data["cacert.pem"]); err == nil {
+		renewal := renewalTime(cert, g8sCertificateAuthority.RenewBefore())
+		certificateAuthority.Status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+		certificateAuthority.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+		certificateAuthority.Status.RenewalTime = &metav1.Time{Time: renewal}
+		c.certificateAuthorityWorkqueue.AddAfter(key, time.Until(renewal))
+	}
+
+	// Only the first object to claim a signerName signs for it, the others are told
+	// which one does
+	conflict, err := c.signerConflict(certificateAuthority, certificateAuthority.Spec.SignerName, certificateAuthority.Status.SignerConflict)
+	if err != nil {
+		return err
+	}
+	certificateAuthority.Status.SignerConflict = conflict
+
+	// Finally, we update the status block of the CertificateAuthority resource to reflect the
+	// current state of the world
+	err = c.updateCertificateAuthorityStatus(certificateAuthority)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(certificateAuthority, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// certificateAuthoritySecretType is the type of a CertificateAuthority's backend Secret
+const certificateAuthoritySecretType corev1.SecretType = "g8s.io/certificate-authority"
+
+// syncTrustBundle publishes the unexpired CA certs of history in the trust bundle
+// Secret of certificateAuthority, replacing the Secret whenever they change. This is
+// the Secret Allowlists propagate, the backend Secret holds the CA key.
+func (c *Controller) syncTrustBundle(ctx context.Context, certificateAuthority *g8sv1alpha1.CertificateAuthority, g8sCertificateAuthority *internalv1alpha1.CertificateAuthority, history *corev1.Secret) error {
+	logger := klog.FromContext(ctx)
+	name := "certificateauthority-" + certificateAuthority.Name + "-trust"
+	bundle := g8sCertificateAuthority.TrustBundle(internalv1alpha1.StringData(history.Data), time.Now())
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(certificateAuthority.Namespace)
+
+	trust, err := c.secretLister.Secrets(certificateAuthority.Namespace).Get(name)
+	if err == nil {
+		if !metav1.IsControlledBy(trust, certificateAuthority) {
+			msg := fmt.Sprintf(MessageResourceExists, trust.Name)
+			c.recorder.Event(certificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if string(trust.Data["cacert.pem"]) == bundle {
+			return nil
+		}
+
+		// immutable like the backend Secret, so it has to be replaced rather than updated
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	logger.V(4).Info("Publish trust bundle Secret resource", "name", name)
+	_, err = secrets.Create(ctx, internalv1alpha1.NewTrustBundleSecret(g8sCertificateAuthority, bundle), metav1.CreateOptions{})
+	return err
+}
+
+func (c *Controller) updateCertificateAuthorityStatus(certificateAuthority *g8sv1alpha1.CertificateAuthority) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	certificateAuthorityCopy := certificateAuthority.DeepCopy()
+	certificateAuthorityCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the CertificateAuthority resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().CertificateAuthorities(certificateAuthority.Namespace).UpdateStatus(context.TODO(), certificateAuthorityCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueCertificateAuthority takes a CertificateAuthority resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other than CertificateAuthority.
+func (c *Controller) enqueueCertificateAuthority(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.certificateAuthorityWorkqueue.Add(key)
+}
+
+// handleCertificateAuthorityObject will take any resource implementing metav1.Object and attempt
+// to find the CertificateAuthority resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that CertificateAuthority resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleCertificateAuthorityObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a CertificateAuthority, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "CertificateAuthority" {
+			return
+		}
+
+		certificateAuthority, err := c.certificateAuthorityLister.CertificateAuthorities(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "certificateAuthority", ownerRef.Name)
+			return
+		}
+
+		c.enqueueCertificateAuthority(certificateAuthority)
+		return
+	}
+}
+
+// Set up an event handler for when CertificateAuthority and/or their backend and history Secret resources change
+func (c *Controller) setCertificateAuthorityInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.certificateAuthorityInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueCertificateAuthority,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueCertificateAuthority(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ca, ok := obj.(*g8sv1alpha1.CertificateAuthority)
+			if !ok {
+				logger.Error(nil, "obj is not a CertificateAuthority")
+			}
+			c.recorder.Event(ca, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when CertificateAuthority backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a CertificateAuthority resource then the handler will enqueue that CertificateAuthority resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleCertificateAuthorityObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleCertificateAuthorityObject(new)
+		},
+		DeleteFunc: c.handleCertificateAuthorityObject,
+	})
+}
@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runCertificateSigningRequestWorker is a long-running function that will continually call the
+// processNextCertificateSigningRequestWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runCertificateSigningRequestWorker(ctx context.Context) {
+	for c.processNextCertificateSigningRequestWorkItem(ctx) {
+	}
+}
+
+// processNextCertificateSigningRequestWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the certificateSigningRequestSyncHandler.
+func (c *Controller) processNextCertificateSigningRequestWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.certificateSigningRequestWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.certificateSigningRequestWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.certificateSigningRequestWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. CertificateSigningRequests
+		// are cluster scoped, so these are just their name.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.certificateSigningRequestWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the certificateSigningRequestSyncHandler, passing it the name string of the
+		// CertificateSigningRequest resource to be synced.
+		if err := c.certificateSigningRequestSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.certificateSigningRequestWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.certificateSigningRequestWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// certificateSigningRequestSyncHandler signs a CertificateSigningRequest once it is
+// approved, if it names a signer that a SelfSignedTLSBundle or CertificateAuthority
+// claimed through spec.signerName. The cert is written to status.certificate, a
+// request that can't be signed gets a Failed condition instead.
+func (c *Controller) certificateSigningRequestSyncHandler(ctx context.Context, key string) error {
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	// Get the CertificateSigningRequest resource with this name
+	csrFromLister, err := c.certificateSigningRequestLister.Get(key)
+	if err != nil {
+		// The CertificateSigningRequest resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// Only approved requests for our signers that weren't handled yet are signed
+	if !strings.HasPrefix(csrFromLister.Spec.SignerName, internalv1alpha1.SignerPrefix) || len(csrFromLister.Status.Certificate) > 0 || !csrApproved(csrFromLister) {
+		return nil
+	}
+
+	// DeepCopy for safety
+	csr := csrFromLister.DeepCopy()
+
+	caCertPEM, caKeyPEM, err := c.signerCA(csr.Spec.SignerName)
+	if err != nil {
+		// another g8s object may claim the signer later, it's requeued then
+		logger.V(4).Info("Cannot sign CertificateSigningRequest", "signerName", csr.Spec.SignerName, "err", err.Error())
+		return nil
+	}
+
+	cert, err := internalv1alpha1.SignCertificateRequest(caCertPEM, caKeyPEM, csr)
+	if err != nil {
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:               certificatesv1.CertificateFailed,
+			Status:             corev1.ConditionTrue,
+			Reason:             "SignerValidationFailure",
+			Message:            err.Error(),
+			LastUpdateTime:     metav1.Now(),
+			LastTransitionTime: metav1.Now(),
+		})
+		c.recorder.Event(csr, corev1.EventTypeWarning, ErrSigningFailed, err.Error())
+	} else {
+		csr.Status.Certificate = []byte(cert)
+	}
+
+	_, err = c.Client.kubeClientset.CertificatesV1().CertificateSigningRequests().UpdateStatus(ctx, csr, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if len(csr.Status.Certificate) > 0 {
+		c.recorder.Eventf(csr, corev1.EventTypeNormal, SuccessSigned, MessageRequestSigned, csr.Spec.SignerName)
+	}
+	return nil
+}
+
+// csrApproved reports whether csr was approved, and neither denied nor failed
+func csrApproved(csr *certificatesv1.CertificateSigningRequest) bool {
+	approved := false
+	for _, condition := range csr.Status.Conditions {
+		switch condition.Type {
+		case certificatesv1.CertificateApproved:
+			approved = condition.Status == corev1.ConditionTrue
+		case certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			if condition.Status == corev1.ConditionTrue {
+				return false
+			}
+		}
+	}
+	return approved
+}
+
+// signerClaim is a SelfSignedTLSBundle or CertificateAuthority that claims a
+// signerName, with the Secret fields its CA is kept in
+type signerClaim struct {
+	kind       string
+	object     metav1.Object
+	secretName string
+	certKey    string
+	keyKey     string
+}
+
+func (s signerClaim) String() string {
+	return s.kind + " " + s.object.GetNamespace() + "/" + s.object.GetName()
+}
+
+// signerClaims returns every SelfSignedTLSBundle and CertificateAuthority that claims
+// signerName, oldest first. Only the oldest signs for it, so that an object created
+// later with the same signerName can't take over or block signing. Ties are broken by
+// kind, namespace and name so that every sync agrees on the order.
+func (c *Controller) signerClaims(signerName string) ([]signerClaim, error) {
+	var claims []signerClaim
+
+	sstlss, err := c.selfSignedTLSBundleLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, sstls := range sstlss {
+		if sstls.Spec.SignerName == signerName {
+			// the newest CA is in the history, the backend Secret only has its cert
+			claims = append(claims, signerClaim{"SelfSignedTLSBundle", sstls, "selfsignedtlsbundle-" + sstls.Name + "-history", "cacert.pem-0", "cakey.pem-0"})
+		}
+	}
+
+	cas, err := c.certificateAuthorityLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, ca := range cas {
+		if ca.Spec.SignerName == signerName {
+			claims = append(claims, signerClaim{"CertificateAuthority", ca, "certificateauthority-" + ca.Name, "cacert.pem", "cakey.pem"})
+		}
+	}
+
+	slices.SortFunc(claims, func(a, b signerClaim) int {
+		if t := a.object.GetCreationTimestamp().Time.Compare(b.object.GetCreationTimestamp().Time); t != 0 {
+			return t
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	return claims, nil
+}
+
+// signerCA returns the PEM encoded cert and key of the CA that signs for signerName.
+// For a SelfSignedTLSBundle that's the newest CA in its history, for a
+// CertificateAuthority the one in its backend Secret. When several objects claim
+// signerName, the oldest one signs.
+func (c *Controller) signerCA(signerName string) (string, string, error) {
+	claims, err := c.signerClaims(signerName)
+	if err != nil {
+		return "", "", err
+	}
+	if len(claims) == 0 {
+		return "", "", fmt.Errorf("no SelfSignedTLSBundle or CertificateAuthority claims signerName %q", signerName)
+	}
+
+	claim := claims[0]
+	secret, err := c.secretLister.Secrets(claim.object.GetNamespace()).Get(claim.secretName)
+	if err != nil {
+		return "", "", err
+	}
+	return string(secret.Data[claim.certKey]), string(secret.Data[claim.keyKey]), nil
+}
+
+// signerConflict returns the object that signs for signerName, the one claimed by
+// object, if that isn't object itself, or "" otherwise. A conflict new to status is
+// reported as a Warning Event on object.
+func (c *Controller) signerConflict(object runtime.Object, signerName, status string) (string, error) {
+	if signerName == "" {
+		return "", nil
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return "", err
+	}
+	claims, err := c.signerClaims(signerName)
+	if err != nil {
+		return "", err
+	}
+	if len(claims) == 0 || claims[0].object.GetUID() == accessor.GetUID() {
+		return "", nil
+	}
+
+	conflict := claims[0].String()
+	if conflict != status {
+		c.recorder.Eventf(object, corev1.EventTypeWarning, ErrSignerConflict, MessageSignerConflict, signerName, conflict)
+	}
+	return conflict, nil
+}
+
+// enqueueCertificateSigningRequest takes a CertificateSigningRequest resource and converts it into a name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other than CertificateSigningRequest.
+func (c *Controller) enqueueCertificateSigningRequest(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.certificateSigningRequestWorkqueue.Add(key)
+}
+
+// enqueuePendingCertificateSigningRequests enqueues every CertificateSigningRequest
+// for one of our signers that wasn't signed yet, so that those waiting for a signer
+// to be claimed are looked at again.
+func (c *Controller) enqueuePendingCertificateSigningRequests(obj any) {
+	csrs, err := c.certificateSigningRequestLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, csr := range csrs {
+		if strings.HasPrefix(csr.Spec.SignerName, internalv1alpha1.SignerPrefix) && len(csr.Status.Certificate) == 0 {
+			c.enqueueCertificateSigningRequest(csr)
+		}
+	}
+}
+
+// Set up an event handler for when CertificateSigningRequests, or the g8s objects that
+// may sign them, change
+func (c *Controller) setCertificateSigningRequestInformersEventHandlers(ctx context.Context) {
+	c.certificateSigningRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueCertificateSigningRequest,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueCertificateSigningRequest(new)
+		},
+	})
+
+	c.selfSignedTLSBundleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueuePendingCertificateSigningRequests,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueuePendingCertificateSigningRequests(new)
+			if old.(*g8sv1alpha1.SelfSignedTLSBundle).Spec.SignerName != new.(*g8sv1alpha1.SelfSignedTLSBundle).Spec.SignerName {
+				c.enqueueSignerClaims(old)
+			}
+		},
+		DeleteFunc: c.enqueueSignerClaims,
+	})
+
+	c.certificateAuthorityInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueuePendingCertificateSigningRequests,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueuePendingCertificateSigningRequests(new)
+			if old.(*g8sv1alpha1.CertificateAuthority).Spec.SignerName != new.(*g8sv1alpha1.CertificateAuthority).Spec.SignerName {
+				c.enqueueSignerClaims(old)
+			}
+		},
+		DeleteFunc: c.enqueueSignerClaims,
+	})
+}
+
+// enqueueSignerClaims enqueues every object that claims the signerName obj claimed,
+// so that the next one in line takes over and their status.signerConflict follows
+// when obj is deleted or gives up its signerName
+func (c *Controller) enqueueSignerClaims(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	var signerName string
+	switch o := obj.(type) {
+	case *g8sv1alpha1.SelfSignedTLSBundle:
+		signerName = o.Spec.SignerName
+	case *g8sv1alpha1.CertificateAuthority:
+		signerName = o.Spec.SignerName
+	}
+	if signerName == "" {
+		return
+	}
+
+	claims, err := c.signerClaims(signerName)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, claim := range claims {
+		switch claim.kind {
+		case "SelfSignedTLSBundle":
+			c.enqueueSelfSignedTLSBundle(claim.object)
+		case "CertificateAuthority":
+			c.enqueueCertificateAuthority(claim.object)
+		}
+	}
+	c.enqueuePendingCertificateSigningRequests(obj)
+}
@@ -1,8 +1,12 @@
 package controller
 
 import (
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	certificatesinformers "k8s.io/client-go/informers/certificates/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -20,22 +24,64 @@ type Client struct {
 	g8sClientset clientset.Interface
 
 	// Informers for each type, just to expose for access
-	allowlistInformer           informers.AllowlistInformer
-	selfSignedTLSBundleInformer informers.SelfSignedTLSBundleInformer
-	loginInformer               informers.LoginInformer
-	sshKeyPairInformer          informers.SSHKeyPairInformer
-	namespaceInformer           coreinformers.NamespaceInformer
-	secretInformer              coreinformers.SecretInformer
+	allowlistInformer                 informers.AllowlistInformer
+	selfSignedTLSBundleInformer       informers.SelfSignedTLSBundleInformer
+	loginInformer                     informers.LoginInformer
+	sshKeyPairInformer                informers.SSHKeyPairInformer
+	certificateAuthorityInformer      informers.CertificateAuthorityInformer
+	certificateInformer               informers.CertificateInformer
+	sshCertificateAuthorityInformer   informers.SSHCertificateAuthorityInformer
+	jwtSigningKeyInformer             informers.JWTSigningKeyInformer
+	randomSecretInformer              informers.RandomSecretInformer
+	apiTokenInformer                  informers.APITokenInformer
+	registryCredentialInformer        informers.RegistryCredentialInformer
+	wireGuardKeyPairInformer          informers.WireGuardKeyPairInformer
+	pgpKeyPairInformer                informers.PGPKeyPairInformer
+	ageKeyPairInformer                informers.AgeKeyPairInformer
+	totpSeedInformer                  informers.TOTPSeedInformer
+	dhParamsInformer                  informers.DHParamsInformer
+	namespaceInformer                 coreinformers.NamespaceInformer
+	secretInformer                    coreinformers.SecretInformer
+	certificateSigningRequestInformer certificatesinformers.CertificateSigningRequestInformer
+	podInformer                       coreinformers.PodInformer
+	replicaSetInformer                appsinformers.ReplicaSetInformer
+	deploymentInformer                appsinformers.DeploymentInformer
+	statefulSetInformer               appsinformers.StatefulSetInformer
+	daemonSetInformer                 appsinformers.DaemonSetInformer
 
 	// listers for our custom types
-	allowlistLister           listers.AllowlistLister
-	allowlistSynced           cache.InformerSynced
-	selfSignedTLSBundleLister listers.SelfSignedTLSBundleLister
-	selfSignedTLSBundleSynced cache.InformerSynced
-	loginLister               listers.LoginLister
-	loginSynced               cache.InformerSynced
-	sshKeyPairLister          listers.SSHKeyPairLister
-	sshKeyPairSynced          cache.InformerSynced
+	allowlistLister               listers.AllowlistLister
+	allowlistSynced               cache.InformerSynced
+	selfSignedTLSBundleLister     listers.SelfSignedTLSBundleLister
+	selfSignedTLSBundleSynced     cache.InformerSynced
+	loginLister                   listers.LoginLister
+	loginSynced                   cache.InformerSynced
+	sshKeyPairLister              listers.SSHKeyPairLister
+	sshKeyPairSynced              cache.InformerSynced
+	certificateAuthorityLister    listers.CertificateAuthorityLister
+	certificateAuthoritySynced    cache.InformerSynced
+	certificateLister             listers.CertificateLister
+	certificateSynced             cache.InformerSynced
+	sshCertificateAuthorityLister listers.SSHCertificateAuthorityLister
+	sshCertificateAuthoritySynced cache.InformerSynced
+	jwtSigningKeyLister           listers.JWTSigningKeyLister
+	jwtSigningKeySynced           cache.InformerSynced
+	randomSecretLister            listers.RandomSecretLister
+	randomSecretSynced            cache.InformerSynced
+	apiTokenLister                listers.APITokenLister
+	apiTokenSynced                cache.InformerSynced
+	registryCredentialLister      listers.RegistryCredentialLister
+	registryCredentialSynced      cache.InformerSynced
+	wireGuardKeyPairLister        listers.WireGuardKeyPairLister
+	wireGuardKeyPairSynced        cache.InformerSynced
+	pgpKeyPairLister              listers.PGPKeyPairLister
+	pgpKeyPairSynced              cache.InformerSynced
+	ageKeyPairLister              listers.AgeKeyPairLister
+	ageKeyPairSynced              cache.InformerSynced
+	totpSeedLister                listers.TOTPSeedLister
+	totpSeedSynced                cache.InformerSynced
+	dhParamsLister                listers.DHParamsLister
+	dhParamsSynced                cache.InformerSynced
 
 	// listers for k8s types owned by our custom types
 	namespaceLister corelisters.NamespaceLister
@@ -43,6 +89,22 @@ type Client struct {
 	secretLister    corelisters.SecretLister
 	secretSynced    cache.InformerSynced
 
+	// listers for CertificateSigningRequests signed by our custom types
+	certificateSigningRequestLister certificateslisters.CertificateSigningRequestLister
+	certificateSigningRequestSynced cache.InformerSynced
+
+	// listers for workloads that consume our backing types
+	podLister         corelisters.PodLister
+	podSynced         cache.InformerSynced
+	replicaSetLister  appslisters.ReplicaSetLister
+	replicaSetSynced  cache.InformerSynced
+	deploymentLister  appslisters.DeploymentLister
+	deploymentSynced  cache.InformerSynced
+	statefulSetLister appslisters.StatefulSetLister
+	statefulSetSynced cache.InformerSynced
+	daemonSetLister   appslisters.DaemonSetLister
+	daemonSetSynced   cache.InformerSynced
+
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	recorder record.EventRecorder
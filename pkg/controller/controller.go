@@ -8,6 +8,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	certificatesinformers "k8s.io/client-go/informers/certificates/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -38,8 +40,26 @@ func NewController(
 	selfSignedTLSBundleInformer informers.SelfSignedTLSBundleInformer,
 	loginInformer informers.LoginInformer,
 	sshKeyPairInformer informers.SSHKeyPairInformer,
+	certificateAuthorityInformer informers.CertificateAuthorityInformer,
+	certificateInformer informers.CertificateInformer,
+	sshCertificateAuthorityInformer informers.SSHCertificateAuthorityInformer,
+	jwtSigningKeyInformer informers.JWTSigningKeyInformer,
+	randomSecretInformer informers.RandomSecretInformer,
+	apiTokenInformer informers.APITokenInformer,
+	registryCredentialInformer informers.RegistryCredentialInformer,
+	wireGuardKeyPairInformer informers.WireGuardKeyPairInformer,
+	pgpKeyPairInformer informers.PGPKeyPairInformer,
+	ageKeyPairInformer informers.AgeKeyPairInformer,
+	totpSeedInformer informers.TOTPSeedInformer,
+	dhParamsInformer informers.DHParamsInformer,
 	namespaceInformer coreinformers.NamespaceInformer,
-	secretInformer coreinformers.SecretInformer) *Controller {
+	secretInformer coreinformers.SecretInformer,
+	certificateSigningRequestInformer certificatesinformers.CertificateSigningRequestInformer,
+	podInformer coreinformers.PodInformer,
+	replicaSetInformer appsinformers.ReplicaSetInformer,
+	deploymentInformer appsinformers.DeploymentInformer,
+	statefulSetInformer appsinformers.StatefulSetInformer,
+	daemonSetInformer appsinformers.DaemonSetInformer) *Controller {
 
 	logger := klog.FromContext(ctx)
 
@@ -66,18 +86,54 @@ func NewController(
 			recorder:      recorder,
 
 			// informers & listers for our custom types
-			allowlistInformer:           allowlistInformer,
-			allowlistLister:             allowlistInformer.Lister(),
-			allowlistSynced:             allowlistInformer.Informer().HasSynced,
-			selfSignedTLSBundleInformer: selfSignedTLSBundleInformer,
-			selfSignedTLSBundleLister:   selfSignedTLSBundleInformer.Lister(),
-			selfSignedTLSBundleSynced:   selfSignedTLSBundleInformer.Informer().HasSynced,
-			loginInformer:               loginInformer,
-			loginLister:                 loginInformer.Lister(),
-			loginSynced:                 loginInformer.Informer().HasSynced,
-			sshKeyPairInformer:          sshKeyPairInformer,
-			sshKeyPairLister:            sshKeyPairInformer.Lister(),
-			sshKeyPairSynced:            sshKeyPairInformer.Informer().HasSynced,
+			allowlistInformer:               allowlistInformer,
+			allowlistLister:                 allowlistInformer.Lister(),
+			allowlistSynced:                 allowlistInformer.Informer().HasSynced,
+			selfSignedTLSBundleInformer:     selfSignedTLSBundleInformer,
+			selfSignedTLSBundleLister:       selfSignedTLSBundleInformer.Lister(),
+			selfSignedTLSBundleSynced:       selfSignedTLSBundleInformer.Informer().HasSynced,
+			loginInformer:                   loginInformer,
+			loginLister:                     loginInformer.Lister(),
+			loginSynced:                     loginInformer.Informer().HasSynced,
+			sshKeyPairInformer:              sshKeyPairInformer,
+			sshKeyPairLister:                sshKeyPairInformer.Lister(),
+			sshKeyPairSynced:                sshKeyPairInformer.Informer().HasSynced,
+			certificateAuthorityInformer:    certificateAuthorityInformer,
+			certificateAuthorityLister:      certificateAuthorityInformer.Lister(),
+			certificateAuthoritySynced:      certificateAuthorityInformer.Informer().HasSynced,
+			certificateInformer:             certificateInformer,
+			certificateLister:               certificateInformer.Lister(),
+			certificateSynced:               certificateInformer.Informer().HasSynced,
+			sshCertificateAuthorityInformer: sshCertificateAuthorityInformer,
+			sshCertificateAuthorityLister:   sshCertificateAuthorityInformer.Lister(),
+			sshCertificateAuthoritySynced:   sshCertificateAuthorityInformer.Informer().HasSynced,
+			jwtSigningKeyInformer:           jwtSigningKeyInformer,
+			jwtSigningKeyLister:             jwtSigningKeyInformer.Lister(),
+			jwtSigningKeySynced:             jwtSigningKeyInformer.Informer().HasSynced,
+			randomSecretInformer:            randomSecretInformer,
+			randomSecretLister:              randomSecretInformer.Lister(),
+			randomSecretSynced:              randomSecretInformer.Informer().HasSynced,
+			apiTokenInformer:                apiTokenInformer,
+			apiTokenLister:                  apiTokenInformer.Lister(),
+			apiTokenSynced:                  apiTokenInformer.Informer().HasSynced,
+			registryCredentialInformer:      registryCredentialInformer,
+			registryCredentialLister:        registryCredentialInformer.Lister(),
+			registryCredentialSynced:        registryCredentialInformer.Informer().HasSynced,
+			wireGuardKeyPairInformer:        wireGuardKeyPairInformer,
+			wireGuardKeyPairLister:          wireGuardKeyPairInformer.Lister(),
+			wireGuardKeyPairSynced:          wireGuardKeyPairInformer.Informer().HasSynced,
+			pgpKeyPairInformer:              pgpKeyPairInformer,
+			pgpKeyPairLister:                pgpKeyPairInformer.Lister(),
+			pgpKeyPairSynced:                pgpKeyPairInformer.Informer().HasSynced,
+			ageKeyPairInformer:              ageKeyPairInformer,
+			ageKeyPairLister:                ageKeyPairInformer.Lister(),
+			ageKeyPairSynced:                ageKeyPairInformer.Informer().HasSynced,
+			totpSeedInformer:                totpSeedInformer,
+			totpSeedLister:                  totpSeedInformer.Lister(),
+			totpSeedSynced:                  totpSeedInformer.Informer().HasSynced,
+			dhParamsInformer:                dhParamsInformer,
+			dhParamsLister:                  dhParamsInformer.Lister(),
+			dhParamsSynced:                  dhParamsInformer.Informer().HasSynced,
 
 			// informers & listers for our backing types
 			namespaceInformer: namespaceInformer,
@@ -86,12 +142,47 @@ func NewController(
 			secretInformer:    secretInformer,
 			secretLister:      secretInformer.Lister(),
 			secretSynced:      secretInformer.Informer().HasSynced,
+
+			// informers & listers for CertificateSigningRequests signed by our types
+			certificateSigningRequestInformer: certificateSigningRequestInformer,
+			certificateSigningRequestLister:   certificateSigningRequestInformer.Lister(),
+			certificateSigningRequestSynced:   certificateSigningRequestInformer.Informer().HasSynced,
+
+			// informers & listers for workloads consuming our backing types
+			podInformer:         podInformer,
+			podLister:           podInformer.Lister(),
+			podSynced:           podInformer.Informer().HasSynced,
+			replicaSetInformer:  replicaSetInformer,
+			replicaSetLister:    replicaSetInformer.Lister(),
+			replicaSetSynced:    replicaSetInformer.Informer().HasSynced,
+			deploymentInformer:  deploymentInformer,
+			deploymentLister:    deploymentInformer.Lister(),
+			deploymentSynced:    deploymentInformer.Informer().HasSynced,
+			statefulSetInformer: statefulSetInformer,
+			statefulSetLister:   statefulSetInformer.Lister(),
+			statefulSetSynced:   statefulSetInformer.Informer().HasSynced,
+			daemonSetInformer:   daemonSetInformer,
+			daemonSetLister:     daemonSetInformer.Lister(),
+			daemonSetSynced:     daemonSetInformer.Informer().HasSynced,
 		},
 		Executor: Executor{
-			allowlistWorkqueue:           workqueue.NewNamedRateLimitingQueue(rateLimiter, "Allowlist"),
-			selfSignedTLSBundleWorkqueue: workqueue.NewNamedRateLimitingQueue(rateLimiter, "SelfSignedTLSBundle"),
-			loginWorkqueue:               workqueue.NewNamedRateLimitingQueue(rateLimiter, "Login"),
-			sshKeyPairWorkqueue:          workqueue.NewNamedRateLimitingQueue(rateLimiter, "SSHKeyPair"),
+			allowlistWorkqueue:                 workqueue.NewNamedRateLimitingQueue(rateLimiter, "Allowlist"),
+			selfSignedTLSBundleWorkqueue:       workqueue.NewNamedRateLimitingQueue(rateLimiter, "SelfSignedTLSBundle"),
+			loginWorkqueue:                     workqueue.NewNamedRateLimitingQueue(rateLimiter, "Login"),
+			sshKeyPairWorkqueue:                workqueue.NewNamedRateLimitingQueue(rateLimiter, "SSHKeyPair"),
+			certificateAuthorityWorkqueue:      workqueue.NewNamedRateLimitingQueue(rateLimiter, "CertificateAuthority"),
+			certificateWorkqueue:               workqueue.NewNamedRateLimitingQueue(rateLimiter, "Certificate"),
+			certificateSigningRequestWorkqueue: workqueue.NewNamedRateLimitingQueue(rateLimiter, "CertificateSigningRequest"),
+			sshCertificateAuthorityWorkqueue:   workqueue.NewNamedRateLimitingQueue(rateLimiter, "SSHCertificateAuthority"),
+			jwtSigningKeyWorkqueue:             workqueue.NewNamedRateLimitingQueue(rateLimiter, "JWTSigningKey"),
+			randomSecretWorkqueue:              workqueue.NewNamedRateLimitingQueue(rateLimiter, "RandomSecret"),
+			apiTokenWorkqueue:                  workqueue.NewNamedRateLimitingQueue(rateLimiter, "APIToken"),
+			registryCredentialWorkqueue:        workqueue.NewNamedRateLimitingQueue(rateLimiter, "RegistryCredential"),
+			wireGuardKeyPairWorkqueue:          workqueue.NewNamedRateLimitingQueue(rateLimiter, "WireGuardKeyPair"),
+			pgpKeyPairWorkqueue:                workqueue.NewNamedRateLimitingQueue(rateLimiter, "PGPKeyPair"),
+			ageKeyPairWorkqueue:                workqueue.NewNamedRateLimitingQueue(rateLimiter, "AgeKeyPair"),
+			totpSeedWorkqueue:                  workqueue.NewNamedRateLimitingQueue(rateLimiter, "TOTPSeed"),
+			dhParamsWorkqueue:                  workqueue.NewNamedRateLimitingQueue(rateLimiter, "DHParams"),
 		},
 	}
 
@@ -101,6 +192,19 @@ func NewController(
 	controller.setLoginInformersEventHandlers(ctx)
 	controller.setSelfSignedTLSBundleInformersEventHandlers(ctx)
 	controller.setSSHKeyPairInformersEventHandlers(ctx)
+	controller.setCertificateAuthorityInformersEventHandlers(ctx)
+	controller.setCertificateInformersEventHandlers(ctx)
+	controller.setCertificateSigningRequestInformersEventHandlers(ctx)
+	controller.setSSHCertificateAuthorityInformersEventHandlers(ctx)
+	controller.setJWTSigningKeyInformersEventHandlers(ctx)
+	controller.setRandomSecretInformersEventHandlers(ctx)
+	controller.setAPITokenInformersEventHandlers(ctx)
+	controller.setRegistryCredentialInformersEventHandlers(ctx)
+	controller.setWireGuardKeyPairInformersEventHandlers(ctx)
+	controller.setPGPKeyPairInformersEventHandlers(ctx)
+	controller.setAgeKeyPairInformersEventHandlers(ctx)
+	controller.setTOTPSeedInformersEventHandlers(ctx)
+	controller.setDHParamsInformersEventHandlers(ctx)
 
 	return controller
 }
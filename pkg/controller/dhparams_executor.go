@@ -0,0 +1,463 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runDHParamsWorker is a long-running function that will continually call the
+// processNextDHParamsWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runDHParamsWorker(ctx context.Context) {
+	for c.processNextDHParamsWorkItem(ctx) {
+	}
+}
+
+// processNextDHParamsWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the dhParamsSyncHandler.
+func (c *Controller) processNextDHParamsWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.dhParamsWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.dhParamsWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.dhParamsWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.dhParamsWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the dhParamsSyncHandler, passing it the namespace/name string of the
+		// DHParams resource to be synced.
+		if err := c.dhParamsSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.dhParamsWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.dhParamsWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// dhParamsSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the DHParams resource
+// with the current status of the resource.
+func (c *Controller) dhParamsSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the DHParams resource with this namespace/name
+	dhParamsFromLister, err := c.dhParamsLister.DHParams(namespace).Get(name)
+	if err != nil {
+		// The DHParams resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			c.dhParamsComputations.cancel(key)
+			utilruntime.HandleError(fmt.Errorf("DHParams '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	dhParams := dhParamsFromLister.DeepCopy()
+
+	backendName := "dhparams-" + dhParams.ObjectMeta.Name
+	historyName := "dhparams-" + dhParams.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(dhParams.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, dhParams.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sDHParams := internalv1alpha1.NewDHParams(dhParams)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sDHParams.Validate(); err != nil {
+		c.recorder.Event(dhParams, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		params, ok, computeErr := c.dhParamsComputations.result(ctx, key, g8sDHParams, c.dhParamsWorkqueue)
+		if !ok {
+			return c.setDHParamsComputing(ctx, dhParams, computeErr)
+		}
+		logger.V(4).Info("Create backend and history Secret resources")
+		historyContent := g8sDHParams.RotateTo(params)
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(dhParams, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sDHParams.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(dhParams.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sDHParams, backendContent, dhParamsSecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		c.dhParamsComputations.forget(key)
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(dhParams.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sDHParams, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sDHParams.BackendContent(internalv1alpha1.StringData(history.Data), dhParams.Status.LiveGeneration)
+		if content == nil {
+			content = g8sDHParams.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(dhParams.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sDHParams, content, dhParamsSecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["dhparam.pem-0"] = string(backend.Data["dhparam.pem"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(dhParams, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(dhParams.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sDHParams, content), metav1.CreateOptions{})
+		dhParams.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this DHParams resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, dhParams) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(dhParams, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, dhParams) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(dhParams, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the DHParams ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     dhParams,
+		g8s:        g8sDHParams,
+		workqueue:  c.dhParamsWorkqueue,
+		rotation:   dhParams.Spec.Rotation,
+		history:    dhParams.Spec.History,
+		status:     &dhParams.Status.RotationStatus,
+		secretType: dhParamsSecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().DHParams(dhParams.Namespace).UpdateStatus(ctx, dhParams, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*dhParams = *updated
+			return nil
+		},
+	}
+	rotation := c.checkRotation(rotated, backend)
+
+	// The new parameters of a rotation are computed off the workers first, the
+	// DHParams is enqueued again once they are
+	if rotation.due() {
+		params, ok, computeErr := c.dhParamsComputations.result(ctx, key, g8sDHParams, c.dhParamsWorkqueue)
+		if !ok {
+			return c.setDHParamsComputing(ctx, dhParams, computeErr)
+		}
+		dhParams.Status.Computing = false
+		rotated.generate = func() (map[string]string, error) {
+			return g8sDHParams.RotateTo(params), nil
+		}
+		backend, history, err = c.rotate(ctx, rotated, &rotation, history, nil)
+		if err != nil {
+			return err
+		}
+		c.dhParamsComputations.forget(key)
+	}
+
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Finally, we update the status block of the DHParams resource to reflect the
+	// current state of the world
+	err = c.updateDHParamsStatus(dhParams)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(dhParams, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// dhParamsSecretType is the type of a DHParams's backend Secret
+const dhParamsSecretType corev1.SecretType = "g8s.io/dh-params"
+
+// setDHParamsComputing records in the status of a DHParams that new parameters are
+// being computed, or reports err if computing them failed
+func (c *Controller) setDHParamsComputing(ctx context.Context, dhParams *g8sv1alpha1.DHParams, err error) error {
+	if err != nil {
+		c.recorder.Event(dhParams, corev1.EventTypeWarning, ErrRotationFailed, err.Error())
+		return err
+	}
+	if dhParams.Status.Computing {
+		return nil
+	}
+
+	dhParams.Status.Computing = true
+	_, err = c.Client.g8sClientset.ApiV1alpha1().DHParams(dhParams.Namespace).UpdateStatus(ctx, dhParams, metav1.UpdateOptions{})
+	return err
+}
+
+// dhParamsComputation is the computation of new parameters for a DHParams
+type dhParamsComputation struct {
+	spec   g8sv1alpha1.DHParamsSpec
+	cancel context.CancelFunc
+
+	done    bool
+	content map[string]string
+	err     error
+}
+
+// dhParamsComputations are the computations of new parameters in flight, keyed by
+// the namespace/name of their DHParams. Computing 4096-bit parameters takes minutes,
+// so they run in goroutines of their own rather than on the workers, which would
+// otherwise be kept from every other queue for as long.
+type dhParamsComputations struct {
+	mu       sync.Mutex
+	inFlight map[string]*dhParamsComputation
+}
+
+// result returns the parameters computed for the DHParams under key and true once
+// its computation is done. Until then it returns false, after starting the
+// computation if there is none for the current spec, and key is enqueued on queue
+// again when it's done. A failed computation is dropped so the next call starts
+// over.
+func (dc *dhParamsComputations) result(ctx context.Context, key string, dh *internalv1alpha1.DHParams, queue workqueue.RateLimitingInterface) (map[string]string, bool, error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if comp, ok := dc.inFlight[key]; ok {
+		if comp.spec.BitSize == dh.Spec.BitSize && comp.spec.Generator == dh.Spec.Generator {
+			if !comp.done {
+				return nil, false, nil
+			}
+			if comp.err != nil {
+				delete(dc.inFlight, key)
+				return nil, false, comp.err
+			}
+			return comp.content, true, nil
+		}
+		// the spec changed since the computation started, its result is of no use
+		comp.cancel()
+	}
+
+	if dc.inFlight == nil {
+		dc.inFlight = make(map[string]*dhParamsComputation)
+	}
+	compCtx, cancel := context.WithCancel(ctx)
+	comp := &dhParamsComputation{spec: dh.Spec, cancel: cancel}
+	dc.inFlight[key] = comp
+
+	go func() {
+		defer utilruntime.HandleCrash()
+		content, err := dh.Compute(compCtx)
+
+		dc.mu.Lock()
+		comp.done, comp.content, comp.err = true, content, err
+		dc.mu.Unlock()
+		if compCtx.Err() == nil {
+			queue.Add(key)
+		}
+	}()
+	return nil, false, nil
+}
+
+// forget drops the result of the computation for key once it has been stored
+func (dc *dhParamsComputations) forget(key string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if comp, ok := dc.inFlight[key]; ok && comp.done {
+		comp.cancel()
+		delete(dc.inFlight, key)
+	}
+}
+
+// cancel stops the computation for key, e.g. because its DHParams was deleted
+func (dc *dhParamsComputations) cancel(key string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if comp, ok := dc.inFlight[key]; ok {
+		comp.cancel()
+		delete(dc.inFlight, key)
+	}
+}
+
+func (c *Controller) updateDHParamsStatus(dhParams *g8sv1alpha1.DHParams) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	dhParamsCopy := dhParams.DeepCopy()
+	dhParamsCopy.Status.Ready = true
+	dhParamsCopy.Status.Computing = false
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the DHParams resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().DHParams(dhParams.Namespace).UpdateStatus(context.TODO(), dhParamsCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueDHParams takes a DHParams resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha DHParams.
+func (c *Controller) enqueueDHParams(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.dhParamsWorkqueue.Add(key)
+}
+
+// handleDHParamsObject will take any resource implementing metav1.Object and attempt
+// to find the DHParams resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that DHParams resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleDHParamsObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a DHParams, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "DHParams" {
+			return
+		}
+
+		dhParams, err := c.dhParamsLister.DHParams(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "dhParams", ownerRef.Name)
+			return
+		}
+
+		c.enqueueDHParams(dhParams)
+		return
+	}
+}
+
+// Set up an event handler for when DHParams and/or their backend and history Secret resources change
+func (c *Controller) setDHParamsInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.dhParamsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueDHParams,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueDHParams(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			// parameters still being computed for a deleted DHParams are of no use
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.dhParamsComputations.cancel(key)
+			}
+			dh, ok := obj.(*g8sv1alpha1.DHParams)
+			if !ok {
+				logger.Error(nil, "obj is not a DHParams")
+			}
+			c.recorder.Event(dh, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when DHParams backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a DHParams resource then the handler will enqueue that DHParams resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleDHParamsObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleDHParamsObject(new)
+		},
+		DeleteFunc: c.handleDHParamsObject,
+	})
+}
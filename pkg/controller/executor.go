@@ -18,9 +18,55 @@ const (
 	// SuccessfulDelete is used when an object and all its dependents are successfully
 	// deleted
 	SuccessDeleted = "Deleted"
+	// SuccessRotated is used as part of the Event 'reason' when the backend Secret
+	// of a g8s object is regenerated
+	SuccessRotated = "Rotated"
+	// SuccessRolledBack is used as part of the Event 'reason' when the backend
+	// Secret of a g8s object is restored from its history
+	SuccessRolledBack = "RolledBack"
+	// SuccessRenewed is used as part of the Event 'reason' when a cert in the
+	// backend Secret of a g8s object is reissued ahead of expiry
+	SuccessRenewed = "Renewed"
+	// SuccessPruned is used as part of the Event 'reason' when generations are
+	// dropped from the history Secret of a g8s object
+	SuccessPruned = "Pruned"
+	// SuccessRestarted is used as part of the Event 'reason' when workloads are
+	// restarted because a Secret they consume changed
+	SuccessRestarted = "Restarted"
+	// SuccessCARotated is used as part of the Event 'reason' when a CA rotation
+	// enters a new phase
+	SuccessCARotated = "CARotated"
+	// SuccessSigned is used as part of the Event 'reason' when a
+	// CertificateSigningRequest is signed
+	SuccessSigned = "Signed"
 	// ErrResourceExists is used as part of the Event 'reason' when a CR fails
 	// to sync due to a Secret of the same name already existing.
 	ErrResourceExists = "ErrResourceExists"
+	// ErrInvalidSpec is used as part of the Event 'reason' when a CR's spec
+	// cannot be acted upon
+	ErrInvalidSpec = "ErrInvalidSpec"
+	// ErrInvalidRotation is used as part of the Event 'reason' when a CR's
+	// rotation policy cannot be parsed
+	ErrInvalidRotation = "ErrInvalidRotation"
+	// ErrRotationFailed is used as part of the Event 'reason' when a CR's backend
+	// Secret could not be rotated
+	ErrRotationFailed = "ErrRotationFailed"
+	// ErrInvalidRollback is used as part of the Event 'reason' when a CR's
+	// rollback-to annotation doesn't name a generation of its history
+	ErrInvalidRollback = "ErrInvalidRollback"
+	// ErrRestartFailed is used as part of the Event 'reason' when a workload
+	// consuming a changed Secret could not be restarted
+	ErrRestartFailed = "ErrRestartFailed"
+	// ErrIssuerNotReady is used as part of the Event 'reason' when the
+	// CertificateAuthority or SSHCertificateAuthority a CR references can't issue
+	// certs yet
+	ErrIssuerNotReady = "ErrIssuerNotReady"
+	// ErrSigningFailed is used as part of the Event 'reason' when a
+	// CertificateSigningRequest can't be signed
+	ErrSigningFailed = "ErrSigningFailed"
+	// ErrSignerConflict is used as part of the Event 'reason' when a CR claims a
+	// signerName that another one claimed first
+	ErrSignerConflict = "ErrSignerConflict"
 
 	// MessageResourceExists is the message used for Events when a resource
 	// fails to sync due to a Secret already existing
@@ -31,6 +77,49 @@ const (
 	// MessageResourceDeleted is the message used for an Event fired when a CR
 	// is synced successfully
 	MessageResourceDeleted = "Resource and all dependent objects deleted successfully"
+	// MessageResourceRotated is the message used for an Event fired when the
+	// backend Secret of a CR is rotated
+	MessageResourceRotated = "Backend Secret %q rotated"
+	// MessageRotationRequested is the message used for an Event fired when the
+	// backend Secret of a CR is rotated because of the rotate-requested-at annotation
+	MessageRotationRequested = "Backend Secret %q rotated as requested at %s"
+	// MessageCertificateRenewed is the message used for an Event fired when a cert
+	// in the backend Secret of a CR is reissued ahead of expiry
+	MessageCertificateRenewed = "Backend Secret %q renewed ahead of expiry"
+	// MessageCertificateReissued is the message used for an Event fired when a
+	// cert in the backend Secret of a CR is reissued because its CA changed
+	MessageCertificateReissued = "Backend Secret %q reissued by the current CA of CertificateAuthority %q"
+	// MessageCARotationPhase is the message used for an Event fired when a CA
+	// rotation of a CR enters a new phase
+	MessageCARotationPhase = "CA rotation of backend Secret %q entered phase %s"
+	// MessageIssuerNotReady is the message used for an Event fired when the
+	// CertificateAuthority a Certificate references can't issue certs yet
+	MessageIssuerNotReady = "CertificateAuthority %q is not ready: %s"
+	// MessageSignerConflict is the message used for an Event fired when a CR
+	// claims a signerName that another one claimed first
+	MessageSignerConflict = "signerName %q is already claimed by %s, which signs for it instead"
+	// MessageSSHIssuerNotReady is the message used for an Event fired when the
+	// SSHCertificateAuthority an SSHKeyPair references can't sign certificates yet
+	MessageSSHIssuerNotReady = "SSHCertificateAuthority %q is not ready: %s"
+	// MessageSSHCertificateReissued is the message used for an Event fired when
+	// the SSH certificate in the backend Secret of a CR is reissued because its CA
+	// changed
+	MessageSSHCertificateReissued = "Backend Secret %q reissued by the current CA of SSHCertificateAuthority %q"
+	// MessageRequestSigned is the message used for an Event fired when a
+	// CertificateSigningRequest is signed
+	MessageRequestSigned = "Signed by the CA of signer %q"
+	// MessageResourceRolledBack is the message used for an Event fired when the
+	// backend Secret of a CR is restored from its history
+	MessageResourceRolledBack = "Backend Secret %q rolled back to generation %d"
+	// MessageInvalidRollback is the message used for an Event fired when the
+	// rollback-to annotation of a CR can't be acted upon
+	MessageInvalidRollback = "Cannot roll back to %q: %d generations in history"
+	// MessageHistoryPruned is the message used for an Event fired when generations
+	// are dropped from the history Secret of a CR
+	MessageHistoryPruned = "History Secret %q pruned by %d generations, %d left"
+	// MessageWorkloadsRestarted is the message used for an Event fired when
+	// workloads are restarted because Secrets they consume changed
+	MessageWorkloadsRestarted = "Restarted %s in namespace %q after Secrets %s changed"
 )
 
 type Executor struct {
@@ -39,10 +128,26 @@ type Executor struct {
 	// means we can ensure we only process a fixed amount of resources at a
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
-	allowlistWorkqueue           workqueue.RateLimitingInterface
-	selfSignedTLSBundleWorkqueue workqueue.RateLimitingInterface
-	loginWorkqueue               workqueue.RateLimitingInterface
-	sshKeyPairWorkqueue          workqueue.RateLimitingInterface
+	allowlistWorkqueue                 workqueue.RateLimitingInterface
+	selfSignedTLSBundleWorkqueue       workqueue.RateLimitingInterface
+	loginWorkqueue                     workqueue.RateLimitingInterface
+	sshKeyPairWorkqueue                workqueue.RateLimitingInterface
+	certificateAuthorityWorkqueue      workqueue.RateLimitingInterface
+	certificateSigningRequestWorkqueue workqueue.RateLimitingInterface
+	certificateWorkqueue               workqueue.RateLimitingInterface
+	sshCertificateAuthorityWorkqueue   workqueue.RateLimitingInterface
+	jwtSigningKeyWorkqueue             workqueue.RateLimitingInterface
+	randomSecretWorkqueue              workqueue.RateLimitingInterface
+	apiTokenWorkqueue                  workqueue.RateLimitingInterface
+	registryCredentialWorkqueue        workqueue.RateLimitingInterface
+	wireGuardKeyPairWorkqueue          workqueue.RateLimitingInterface
+	pgpKeyPairWorkqueue                workqueue.RateLimitingInterface
+	ageKeyPairWorkqueue                workqueue.RateLimitingInterface
+	totpSeedWorkqueue                  workqueue.RateLimitingInterface
+	dhParamsWorkqueue                  workqueue.RateLimitingInterface
+
+	// dhParamsComputations are the computations of DHParams running off the workers
+	dhParamsComputations dhParamsComputations
 }
 
 // Run will set up the event handlers for types we are interested in, as well
@@ -55,6 +160,19 @@ func (c *Controller) Run(ctx context.Context, workers int) error {
 	defer c.loginWorkqueue.ShutDown()
 	defer c.selfSignedTLSBundleWorkqueue.ShutDown()
 	defer c.sshKeyPairWorkqueue.ShutDown()
+	defer c.certificateAuthorityWorkqueue.ShutDown()
+	defer c.certificateWorkqueue.ShutDown()
+	defer c.certificateSigningRequestWorkqueue.ShutDown()
+	defer c.sshCertificateAuthorityWorkqueue.ShutDown()
+	defer c.jwtSigningKeyWorkqueue.ShutDown()
+	defer c.randomSecretWorkqueue.ShutDown()
+	defer c.apiTokenWorkqueue.ShutDown()
+	defer c.registryCredentialWorkqueue.ShutDown()
+	defer c.wireGuardKeyPairWorkqueue.ShutDown()
+	defer c.pgpKeyPairWorkqueue.ShutDown()
+	defer c.ageKeyPairWorkqueue.ShutDown()
+	defer c.totpSeedWorkqueue.ShutDown()
+	defer c.dhParamsWorkqueue.ShutDown()
 	logger := klog.FromContext(ctx)
 
 	// Start the informer factories to begin populating the informer caches
@@ -63,7 +181,8 @@ func (c *Controller) Run(ctx context.Context, workers int) error {
 	// Wait for the caches to be synced before starting workers
 	logger.Info("Waiting for informer caches to sync")
 
-	if ok := cache.WaitForCacheSync(ctx.Done(), c.loginSynced, c.sshKeyPairSynced, c.secretSynced); !ok {
+	if ok := cache.WaitForCacheSync(ctx.Done(), c.loginSynced, c.sshKeyPairSynced, c.certificateAuthoritySynced, c.certificateSynced, c.secretSynced, c.certificateSigningRequestSynced, c.sshCertificateAuthoritySynced, c.jwtSigningKeySynced, c.randomSecretSynced, c.apiTokenSynced, c.registryCredentialSynced, c.wireGuardKeyPairSynced, c.pgpKeyPairSynced, c.ageKeyPairSynced, c.totpSeedSynced, c.dhParamsSynced,
+		c.podSynced, c.replicaSetSynced, c.deploymentSynced, c.statefulSetSynced, c.daemonSetSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
@@ -75,6 +194,19 @@ func (c *Controller) Run(ctx context.Context, workers int) error {
 		go wait.UntilWithContext(ctx, c.runSelfSignedTLSBundleWorker, time.Second)
 		go wait.UntilWithContext(ctx, c.runLoginWorker, time.Second)
 		go wait.UntilWithContext(ctx, c.runSSHKeyPairWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runCertificateAuthorityWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runCertificateWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runCertificateSigningRequestWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runSSHCertificateAuthorityWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runJWTSigningKeyWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runRandomSecretWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runAPITokenWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runRegistryCredentialWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runWireGuardKeyPairWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runPGPKeyPairWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runAgeKeyPairWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runTOTPSeedWorker, time.Second)
+		go wait.UntilWithContext(ctx, c.runDHParamsWorker, time.Second)
 	}
 
 	logger.Info("Started workers")
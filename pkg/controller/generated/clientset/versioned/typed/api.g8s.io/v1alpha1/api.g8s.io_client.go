@@ -27,10 +27,22 @@ import (
 
 type ApiV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	APITokensGetter
+	AgeKeyPairsGetter
 	AllowlistsGetter
+	CertificatesGetter
+	CertificateAuthoritiesGetter
+	DHParamsGetter
+	JWTSigningKeysGetter
 	LoginsGetter
+	PGPKeyPairsGetter
+	RandomSecretsGetter
+	RegistryCredentialsGetter
+	SSHCertificateAuthoritiesGetter
 	SSHKeyPairsGetter
 	SelfSignedTLSBundlesGetter
+	TOTPSeedsGetter
+	WireGuardKeyPairsGetter
 }
 
 // ApiV1alpha1Client is used to interact with features provided by the api.g8s.io group.
@@ -38,14 +50,54 @@ type ApiV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ApiV1alpha1Client) APITokens(namespace string) APITokenInterface {
+	return newAPITokens(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) AgeKeyPairs(namespace string) AgeKeyPairInterface {
+	return newAgeKeyPairs(c, namespace)
+}
+
 func (c *ApiV1alpha1Client) Allowlists() AllowlistInterface {
 	return newAllowlists(c)
 }
 
+func (c *ApiV1alpha1Client) Certificates(namespace string) CertificateInterface {
+	return newCertificates(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) CertificateAuthorities(namespace string) CertificateAuthorityInterface {
+	return newCertificateAuthorities(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) DHParams(namespace string) DHParamsInterface {
+	return newDHParams(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) JWTSigningKeys(namespace string) JWTSigningKeyInterface {
+	return newJWTSigningKeys(c, namespace)
+}
+
 func (c *ApiV1alpha1Client) Logins(namespace string) LoginInterface {
 	return newLogins(c, namespace)
 }
 
+func (c *ApiV1alpha1Client) PGPKeyPairs(namespace string) PGPKeyPairInterface {
+	return newPGPKeyPairs(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) RandomSecrets(namespace string) RandomSecretInterface {
+	return newRandomSecrets(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) RegistryCredentials(namespace string) RegistryCredentialInterface {
+	return newRegistryCredentials(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) SSHCertificateAuthorities(namespace string) SSHCertificateAuthorityInterface {
+	return newSSHCertificateAuthorities(c, namespace)
+}
+
 func (c *ApiV1alpha1Client) SSHKeyPairs(namespace string) SSHKeyPairInterface {
 	return newSSHKeyPairs(c, namespace)
 }
@@ -54,6 +106,14 @@ func (c *ApiV1alpha1Client) SelfSignedTLSBundles(namespace string) SelfSignedTLS
 	return newSelfSignedTLSBundles(c, namespace)
 }
 
+func (c *ApiV1alpha1Client) TOTPSeeds(namespace string) TOTPSeedInterface {
+	return newTOTPSeeds(c, namespace)
+}
+
+func (c *ApiV1alpha1Client) WireGuardKeyPairs(namespace string) WireGuardKeyPairInterface {
+	return newWireGuardKeyPairs(c, namespace)
+}
+
 // NewForConfig creates a new ApiV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
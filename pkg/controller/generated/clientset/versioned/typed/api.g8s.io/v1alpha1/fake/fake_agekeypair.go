@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAgeKeyPairs implements AgeKeyPairInterface
+type FakeAgeKeyPairs struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var agekeypairsResource = v1alpha1.SchemeGroupVersion.WithResource("agekeypairs")
+
+var agekeypairsKind = v1alpha1.SchemeGroupVersion.WithKind("AgeKeyPair")
+
+// Get takes name of the ageKeyPair, and returns the corresponding ageKeyPair object, and an error if there is any.
+func (c *FakeAgeKeyPairs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.AgeKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(agekeypairsResource, c.ns, name), &v1alpha1.AgeKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AgeKeyPair), err
+}
+
+// List takes label and field selectors, and returns the list of AgeKeyPairs that match those selectors.
+func (c *FakeAgeKeyPairs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.AgeKeyPairList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(agekeypairsResource, agekeypairsKind, c.ns, opts), &v1alpha1.AgeKeyPairList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.AgeKeyPairList{ListMeta: obj.(*v1alpha1.AgeKeyPairList).ListMeta}
+	for _, item := range obj.(*v1alpha1.AgeKeyPairList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested ageKeyPairs.
+func (c *FakeAgeKeyPairs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(agekeypairsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a ageKeyPair and creates it.  Returns the server's representation of the ageKeyPair, and an error, if there is any.
+func (c *FakeAgeKeyPairs) Create(ctx context.Context, ageKeyPair *v1alpha1.AgeKeyPair, opts v1.CreateOptions) (result *v1alpha1.AgeKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(agekeypairsResource, c.ns, ageKeyPair), &v1alpha1.AgeKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AgeKeyPair), err
+}
+
+// Update takes the representation of a ageKeyPair and updates it. Returns the server's representation of the ageKeyPair, and an error, if there is any.
+func (c *FakeAgeKeyPairs) Update(ctx context.Context, ageKeyPair *v1alpha1.AgeKeyPair, opts v1.UpdateOptions) (result *v1alpha1.AgeKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(agekeypairsResource, c.ns, ageKeyPair), &v1alpha1.AgeKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AgeKeyPair), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAgeKeyPairs) UpdateStatus(ctx context.Context, ageKeyPair *v1alpha1.AgeKeyPair, opts v1.UpdateOptions) (*v1alpha1.AgeKeyPair, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(agekeypairsResource, "status", c.ns, ageKeyPair), &v1alpha1.AgeKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AgeKeyPair), err
+}
+
+// Delete takes name of the ageKeyPair and deletes it. Returns an error if one occurs.
+func (c *FakeAgeKeyPairs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(agekeypairsResource, c.ns, name, opts), &v1alpha1.AgeKeyPair{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAgeKeyPairs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(agekeypairsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.AgeKeyPairList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched ageKeyPair.
+func (c *FakeAgeKeyPairs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AgeKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(agekeypairsResource, c.ns, name, pt, data, subresources...), &v1alpha1.AgeKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AgeKeyPair), err
+}
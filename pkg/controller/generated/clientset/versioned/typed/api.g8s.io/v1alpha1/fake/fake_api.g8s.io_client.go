@@ -27,14 +27,54 @@ type FakeApiV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeApiV1alpha1) APITokens(namespace string) v1alpha1.APITokenInterface {
+	return &FakeAPITokens{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) AgeKeyPairs(namespace string) v1alpha1.AgeKeyPairInterface {
+	return &FakeAgeKeyPairs{c, namespace}
+}
+
 func (c *FakeApiV1alpha1) Allowlists() v1alpha1.AllowlistInterface {
 	return &FakeAllowlists{c}
 }
 
+func (c *FakeApiV1alpha1) Certificates(namespace string) v1alpha1.CertificateInterface {
+	return &FakeCertificates{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) CertificateAuthorities(namespace string) v1alpha1.CertificateAuthorityInterface {
+	return &FakeCertificateAuthorities{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) DHParams(namespace string) v1alpha1.DHParamsInterface {
+	return &FakeDHParams{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) JWTSigningKeys(namespace string) v1alpha1.JWTSigningKeyInterface {
+	return &FakeJWTSigningKeys{c, namespace}
+}
+
 func (c *FakeApiV1alpha1) Logins(namespace string) v1alpha1.LoginInterface {
 	return &FakeLogins{c, namespace}
 }
 
+func (c *FakeApiV1alpha1) PGPKeyPairs(namespace string) v1alpha1.PGPKeyPairInterface {
+	return &FakePGPKeyPairs{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) RandomSecrets(namespace string) v1alpha1.RandomSecretInterface {
+	return &FakeRandomSecrets{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) RegistryCredentials(namespace string) v1alpha1.RegistryCredentialInterface {
+	return &FakeRegistryCredentials{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) SSHCertificateAuthorities(namespace string) v1alpha1.SSHCertificateAuthorityInterface {
+	return &FakeSSHCertificateAuthorities{c, namespace}
+}
+
 func (c *FakeApiV1alpha1) SSHKeyPairs(namespace string) v1alpha1.SSHKeyPairInterface {
 	return &FakeSSHKeyPairs{c, namespace}
 }
@@ -43,6 +83,14 @@ func (c *FakeApiV1alpha1) SelfSignedTLSBundles(namespace string) v1alpha1.SelfSi
 	return &FakeSelfSignedTLSBundles{c, namespace}
 }
 
+func (c *FakeApiV1alpha1) TOTPSeeds(namespace string) v1alpha1.TOTPSeedInterface {
+	return &FakeTOTPSeeds{c, namespace}
+}
+
+func (c *FakeApiV1alpha1) WireGuardKeyPairs(namespace string) v1alpha1.WireGuardKeyPairInterface {
+	return &FakeWireGuardKeyPairs{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeApiV1alpha1) RESTClient() rest.Interface {
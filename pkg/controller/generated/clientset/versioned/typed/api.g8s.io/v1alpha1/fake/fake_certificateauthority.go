@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCertificateAuthorities implements CertificateAuthorityInterface
+type FakeCertificateAuthorities struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var certificateauthoritiesResource = v1alpha1.SchemeGroupVersion.WithResource("certificateauthorities")
+
+var certificateauthoritiesKind = v1alpha1.SchemeGroupVersion.WithKind("CertificateAuthority")
+
+// Get takes name of the certificateAuthority, and returns the corresponding certificateAuthority object, and an error if there is any.
+func (c *FakeCertificateAuthorities) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.CertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(certificateauthoritiesResource, c.ns, name), &v1alpha1.CertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.CertificateAuthority), err
+}
+
+// List takes label and field selectors, and returns the list of CertificateAuthorities that match those selectors.
+func (c *FakeCertificateAuthorities) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.CertificateAuthorityList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(certificateauthoritiesResource, certificateauthoritiesKind, c.ns, opts), &v1alpha1.CertificateAuthorityList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.CertificateAuthorityList{ListMeta: obj.(*v1alpha1.CertificateAuthorityList).ListMeta}
+	for _, item := range obj.(*v1alpha1.CertificateAuthorityList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested certificateAuthorities.
+func (c *FakeCertificateAuthorities) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(certificateauthoritiesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a certificateAuthority and creates it.  Returns the server's representation of the certificateAuthority, and an error, if there is any.
+func (c *FakeCertificateAuthorities) Create(ctx context.Context, certificateAuthority *v1alpha1.CertificateAuthority, opts v1.CreateOptions) (result *v1alpha1.CertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(certificateauthoritiesResource, c.ns, certificateAuthority), &v1alpha1.CertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.CertificateAuthority), err
+}
+
+// Update takes the representation of a certificateAuthority and updates it. Returns the server's representation of the certificateAuthority, and an error, if there is any.
+func (c *FakeCertificateAuthorities) Update(ctx context.Context, certificateAuthority *v1alpha1.CertificateAuthority, opts v1.UpdateOptions) (result *v1alpha1.CertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(certificateauthoritiesResource, c.ns, certificateAuthority), &v1alpha1.CertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.CertificateAuthority), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCertificateAuthorities) UpdateStatus(ctx context.Context, certificateAuthority *v1alpha1.CertificateAuthority, opts v1.UpdateOptions) (*v1alpha1.CertificateAuthority, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(certificateauthoritiesResource, "status", c.ns, certificateAuthority), &v1alpha1.CertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.CertificateAuthority), err
+}
+
+// Delete takes name of the certificateAuthority and deletes it. Returns an error if one occurs.
+func (c *FakeCertificateAuthorities) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(certificateauthoritiesResource, c.ns, name, opts), &v1alpha1.CertificateAuthority{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCertificateAuthorities) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(certificateauthoritiesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.CertificateAuthorityList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched certificateAuthority.
+func (c *FakeCertificateAuthorities) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.CertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(certificateauthoritiesResource, c.ns, name, pt, data, subresources...), &v1alpha1.CertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.CertificateAuthority), err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDHParams implements DHParamsInterface
+type FakeDHParams struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var dhparamsResource = v1alpha1.SchemeGroupVersion.WithResource("dhparams")
+
+var dhparamsKind = v1alpha1.SchemeGroupVersion.WithKind("DHParams")
+
+// Get takes name of the dHParams, and returns the corresponding dHParams object, and an error if there is any.
+func (c *FakeDHParams) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DHParams, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dhparamsResource, c.ns, name), &v1alpha1.DHParams{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DHParams), err
+}
+
+// List takes label and field selectors, and returns the list of DHParams that match those selectors.
+func (c *FakeDHParams) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DHParamsList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dhparamsResource, dhparamsKind, c.ns, opts), &v1alpha1.DHParamsList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.DHParamsList{ListMeta: obj.(*v1alpha1.DHParamsList).ListMeta}
+	for _, item := range obj.(*v1alpha1.DHParamsList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dHParams.
+func (c *FakeDHParams) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dhparamsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dHParams and creates it.  Returns the server's representation of the dHParams, and an error, if there is any.
+func (c *FakeDHParams) Create(ctx context.Context, dHParams *v1alpha1.DHParams, opts v1.CreateOptions) (result *v1alpha1.DHParams, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dhparamsResource, c.ns, dHParams), &v1alpha1.DHParams{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DHParams), err
+}
+
+// Update takes the representation of a dHParams and updates it. Returns the server's representation of the dHParams, and an error, if there is any.
+func (c *FakeDHParams) Update(ctx context.Context, dHParams *v1alpha1.DHParams, opts v1.UpdateOptions) (result *v1alpha1.DHParams, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dhparamsResource, c.ns, dHParams), &v1alpha1.DHParams{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DHParams), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDHParams) UpdateStatus(ctx context.Context, dHParams *v1alpha1.DHParams, opts v1.UpdateOptions) (*v1alpha1.DHParams, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(dhparamsResource, "status", c.ns, dHParams), &v1alpha1.DHParams{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DHParams), err
+}
+
+// Delete takes name of the dHParams and deletes it. Returns an error if one occurs.
+func (c *FakeDHParams) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(dhparamsResource, c.ns, name, opts), &v1alpha1.DHParams{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDHParams) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dhparamsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.DHParamsList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dHParams.
+func (c *FakeDHParams) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DHParams, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dhparamsResource, c.ns, name, pt, data, subresources...), &v1alpha1.DHParams{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DHParams), err
+}
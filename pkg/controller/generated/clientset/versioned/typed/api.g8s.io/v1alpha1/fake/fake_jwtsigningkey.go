@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeJWTSigningKeys implements JWTSigningKeyInterface
+type FakeJWTSigningKeys struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var jwtsigningkeysResource = v1alpha1.SchemeGroupVersion.WithResource("jwtsigningkeys")
+
+var jwtsigningkeysKind = v1alpha1.SchemeGroupVersion.WithKind("JWTSigningKey")
+
+// Get takes name of the jWTSigningKey, and returns the corresponding jWTSigningKey object, and an error if there is any.
+func (c *FakeJWTSigningKeys) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.JWTSigningKey, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(jwtsigningkeysResource, c.ns, name), &v1alpha1.JWTSigningKey{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.JWTSigningKey), err
+}
+
+// List takes label and field selectors, and returns the list of JWTSigningKeys that match those selectors.
+func (c *FakeJWTSigningKeys) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.JWTSigningKeyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(jwtsigningkeysResource, jwtsigningkeysKind, c.ns, opts), &v1alpha1.JWTSigningKeyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.JWTSigningKeyList{ListMeta: obj.(*v1alpha1.JWTSigningKeyList).ListMeta}
+	for _, item := range obj.(*v1alpha1.JWTSigningKeyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested jWTSigningKeys.
+func (c *FakeJWTSigningKeys) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(jwtsigningkeysResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a jWTSigningKey and creates it.  Returns the server's representation of the jWTSigningKey, and an error, if there is any.
+func (c *FakeJWTSigningKeys) Create(ctx context.Context, jWTSigningKey *v1alpha1.JWTSigningKey, opts v1.CreateOptions) (result *v1alpha1.JWTSigningKey, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(jwtsigningkeysResource, c.ns, jWTSigningKey), &v1alpha1.JWTSigningKey{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.JWTSigningKey), err
+}
+
+// Update takes the representation of a jWTSigningKey and updates it. Returns the server's representation of the jWTSigningKey, and an error, if there is any.
+func (c *FakeJWTSigningKeys) Update(ctx context.Context, jWTSigningKey *v1alpha1.JWTSigningKey, opts v1.UpdateOptions) (result *v1alpha1.JWTSigningKey, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(jwtsigningkeysResource, c.ns, jWTSigningKey), &v1alpha1.JWTSigningKey{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.JWTSigningKey), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeJWTSigningKeys) UpdateStatus(ctx context.Context, jWTSigningKey *v1alpha1.JWTSigningKey, opts v1.UpdateOptions) (*v1alpha1.JWTSigningKey, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(jwtsigningkeysResource, "status", c.ns, jWTSigningKey), &v1alpha1.JWTSigningKey{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.JWTSigningKey), err
+}
+
+// Delete takes name of the jWTSigningKey and deletes it. Returns an error if one occurs.
+func (c *FakeJWTSigningKeys) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(jwtsigningkeysResource, c.ns, name, opts), &v1alpha1.JWTSigningKey{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeJWTSigningKeys) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(jwtsigningkeysResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.JWTSigningKeyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched jWTSigningKey.
+func (c *FakeJWTSigningKeys) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.JWTSigningKey, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(jwtsigningkeysResource, c.ns, name, pt, data, subresources...), &v1alpha1.JWTSigningKey{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.JWTSigningKey), err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePGPKeyPairs implements PGPKeyPairInterface
+type FakePGPKeyPairs struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var pgpkeypairsResource = v1alpha1.SchemeGroupVersion.WithResource("pgpkeypairs")
+
+var pgpkeypairsKind = v1alpha1.SchemeGroupVersion.WithKind("PGPKeyPair")
+
+// Get takes name of the pGPKeyPair, and returns the corresponding pGPKeyPair object, and an error if there is any.
+func (c *FakePGPKeyPairs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(pgpkeypairsResource, c.ns, name), &v1alpha1.PGPKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PGPKeyPair), err
+}
+
+// List takes label and field selectors, and returns the list of PGPKeyPairs that match those selectors.
+func (c *FakePGPKeyPairs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PGPKeyPairList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(pgpkeypairsResource, pgpkeypairsKind, c.ns, opts), &v1alpha1.PGPKeyPairList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.PGPKeyPairList{ListMeta: obj.(*v1alpha1.PGPKeyPairList).ListMeta}
+	for _, item := range obj.(*v1alpha1.PGPKeyPairList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested pGPKeyPairs.
+func (c *FakePGPKeyPairs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(pgpkeypairsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a pGPKeyPair and creates it.  Returns the server's representation of the pGPKeyPair, and an error, if there is any.
+func (c *FakePGPKeyPairs) Create(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.CreateOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(pgpkeypairsResource, c.ns, pGPKeyPair), &v1alpha1.PGPKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PGPKeyPair), err
+}
+
+// Update takes the representation of a pGPKeyPair and updates it. Returns the server's representation of the pGPKeyPair, and an error, if there is any.
+func (c *FakePGPKeyPairs) Update(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(pgpkeypairsResource, c.ns, pGPKeyPair), &v1alpha1.PGPKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PGPKeyPair), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePGPKeyPairs) UpdateStatus(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (*v1alpha1.PGPKeyPair, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(pgpkeypairsResource, "status", c.ns, pGPKeyPair), &v1alpha1.PGPKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PGPKeyPair), err
+}
+
+// Delete takes name of the pGPKeyPair and deletes it. Returns an error if one occurs.
+func (c *FakePGPKeyPairs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(pgpkeypairsResource, c.ns, name, opts), &v1alpha1.PGPKeyPair{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePGPKeyPairs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(pgpkeypairsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.PGPKeyPairList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched pGPKeyPair.
+func (c *FakePGPKeyPairs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PGPKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(pgpkeypairsResource, c.ns, name, pt, data, subresources...), &v1alpha1.PGPKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PGPKeyPair), err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRandomSecrets implements RandomSecretInterface
+type FakeRandomSecrets struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var randomsecretsResource = v1alpha1.SchemeGroupVersion.WithResource("randomsecrets")
+
+var randomsecretsKind = v1alpha1.SchemeGroupVersion.WithKind("RandomSecret")
+
+// Get takes name of the randomSecret, and returns the corresponding randomSecret object, and an error if there is any.
+func (c *FakeRandomSecrets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RandomSecret, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(randomsecretsResource, c.ns, name), &v1alpha1.RandomSecret{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RandomSecret), err
+}
+
+// List takes label and field selectors, and returns the list of RandomSecrets that match those selectors.
+func (c *FakeRandomSecrets) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RandomSecretList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(randomsecretsResource, randomsecretsKind, c.ns, opts), &v1alpha1.RandomSecretList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.RandomSecretList{ListMeta: obj.(*v1alpha1.RandomSecretList).ListMeta}
+	for _, item := range obj.(*v1alpha1.RandomSecretList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested randomSecrets.
+func (c *FakeRandomSecrets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(randomsecretsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a randomSecret and creates it.  Returns the server's representation of the randomSecret, and an error, if there is any.
+func (c *FakeRandomSecrets) Create(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.CreateOptions) (result *v1alpha1.RandomSecret, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(randomsecretsResource, c.ns, randomSecret), &v1alpha1.RandomSecret{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RandomSecret), err
+}
+
+// Update takes the representation of a randomSecret and updates it. Returns the server's representation of the randomSecret, and an error, if there is any.
+func (c *FakeRandomSecrets) Update(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (result *v1alpha1.RandomSecret, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(randomsecretsResource, c.ns, randomSecret), &v1alpha1.RandomSecret{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RandomSecret), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRandomSecrets) UpdateStatus(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (*v1alpha1.RandomSecret, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(randomsecretsResource, "status", c.ns, randomSecret), &v1alpha1.RandomSecret{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RandomSecret), err
+}
+
+// Delete takes name of the randomSecret and deletes it. Returns an error if one occurs.
+func (c *FakeRandomSecrets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(randomsecretsResource, c.ns, name, opts), &v1alpha1.RandomSecret{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRandomSecrets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(randomsecretsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.RandomSecretList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched randomSecret.
+func (c *FakeRandomSecrets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RandomSecret, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(randomsecretsResource, c.ns, name, pt, data, subresources...), &v1alpha1.RandomSecret{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RandomSecret), err
+}
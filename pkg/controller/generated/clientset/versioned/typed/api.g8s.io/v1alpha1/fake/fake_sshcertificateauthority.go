@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSSHCertificateAuthorities implements SSHCertificateAuthorityInterface
+type FakeSSHCertificateAuthorities struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var sshcertificateauthoritiesResource = v1alpha1.SchemeGroupVersion.WithResource("sshcertificateauthorities")
+
+var sshcertificateauthoritiesKind = v1alpha1.SchemeGroupVersion.WithKind("SSHCertificateAuthority")
+
+// Get takes name of the sSHCertificateAuthority, and returns the corresponding sSHCertificateAuthority object, and an error if there is any.
+func (c *FakeSSHCertificateAuthorities) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.SSHCertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(sshcertificateauthoritiesResource, c.ns, name), &v1alpha1.SSHCertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), err
+}
+
+// List takes label and field selectors, and returns the list of SSHCertificateAuthorities that match those selectors.
+func (c *FakeSSHCertificateAuthorities) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.SSHCertificateAuthorityList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(sshcertificateauthoritiesResource, sshcertificateauthoritiesKind, c.ns, opts), &v1alpha1.SSHCertificateAuthorityList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.SSHCertificateAuthorityList{ListMeta: obj.(*v1alpha1.SSHCertificateAuthorityList).ListMeta}
+	for _, item := range obj.(*v1alpha1.SSHCertificateAuthorityList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested sSHCertificateAuthorities.
+func (c *FakeSSHCertificateAuthorities) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(sshcertificateauthoritiesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a sSHCertificateAuthority and creates it.  Returns the server's representation of the sSHCertificateAuthority, and an error, if there is any.
+func (c *FakeSSHCertificateAuthorities) Create(ctx context.Context, sSHCertificateAuthority *v1alpha1.SSHCertificateAuthority, opts v1.CreateOptions) (result *v1alpha1.SSHCertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(sshcertificateauthoritiesResource, c.ns, sSHCertificateAuthority), &v1alpha1.SSHCertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), err
+}
+
+// Update takes the representation of a sSHCertificateAuthority and updates it. Returns the server's representation of the sSHCertificateAuthority, and an error, if there is any.
+func (c *FakeSSHCertificateAuthorities) Update(ctx context.Context, sSHCertificateAuthority *v1alpha1.SSHCertificateAuthority, opts v1.UpdateOptions) (result *v1alpha1.SSHCertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(sshcertificateauthoritiesResource, c.ns, sSHCertificateAuthority), &v1alpha1.SSHCertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSSHCertificateAuthorities) UpdateStatus(ctx context.Context, sSHCertificateAuthority *v1alpha1.SSHCertificateAuthority, opts v1.UpdateOptions) (*v1alpha1.SSHCertificateAuthority, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(sshcertificateauthoritiesResource, "status", c.ns, sSHCertificateAuthority), &v1alpha1.SSHCertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), err
+}
+
+// Delete takes name of the sSHCertificateAuthority and deletes it. Returns an error if one occurs.
+func (c *FakeSSHCertificateAuthorities) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(sshcertificateauthoritiesResource, c.ns, name, opts), &v1alpha1.SSHCertificateAuthority{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSSHCertificateAuthorities) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(sshcertificateauthoritiesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.SSHCertificateAuthorityList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched sSHCertificateAuthority.
+func (c *FakeSSHCertificateAuthorities) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SSHCertificateAuthority, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(sshcertificateauthoritiesResource, c.ns, name, pt, data, subresources...), &v1alpha1.SSHCertificateAuthority{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), err
+}
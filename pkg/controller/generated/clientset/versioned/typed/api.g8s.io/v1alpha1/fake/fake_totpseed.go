@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTOTPSeeds implements TOTPSeedInterface
+type FakeTOTPSeeds struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var totpseedsResource = v1alpha1.SchemeGroupVersion.WithResource("totpseeds")
+
+var totpseedsKind = v1alpha1.SchemeGroupVersion.WithKind("TOTPSeed")
+
+// Get takes name of the tOTPSeed, and returns the corresponding tOTPSeed object, and an error if there is any.
+func (c *FakeTOTPSeeds) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TOTPSeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(totpseedsResource, c.ns, name), &v1alpha1.TOTPSeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TOTPSeed), err
+}
+
+// List takes label and field selectors, and returns the list of TOTPSeeds that match those selectors.
+func (c *FakeTOTPSeeds) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TOTPSeedList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(totpseedsResource, totpseedsKind, c.ns, opts), &v1alpha1.TOTPSeedList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TOTPSeedList{ListMeta: obj.(*v1alpha1.TOTPSeedList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TOTPSeedList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested tOTPSeeds.
+func (c *FakeTOTPSeeds) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(totpseedsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a tOTPSeed and creates it.  Returns the server's representation of the tOTPSeed, and an error, if there is any.
+func (c *FakeTOTPSeeds) Create(ctx context.Context, tOTPSeed *v1alpha1.TOTPSeed, opts v1.CreateOptions) (result *v1alpha1.TOTPSeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(totpseedsResource, c.ns, tOTPSeed), &v1alpha1.TOTPSeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TOTPSeed), err
+}
+
+// Update takes the representation of a tOTPSeed and updates it. Returns the server's representation of the tOTPSeed, and an error, if there is any.
+func (c *FakeTOTPSeeds) Update(ctx context.Context, tOTPSeed *v1alpha1.TOTPSeed, opts v1.UpdateOptions) (result *v1alpha1.TOTPSeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(totpseedsResource, c.ns, tOTPSeed), &v1alpha1.TOTPSeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TOTPSeed), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTOTPSeeds) UpdateStatus(ctx context.Context, tOTPSeed *v1alpha1.TOTPSeed, opts v1.UpdateOptions) (*v1alpha1.TOTPSeed, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(totpseedsResource, "status", c.ns, tOTPSeed), &v1alpha1.TOTPSeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TOTPSeed), err
+}
+
+// Delete takes name of the tOTPSeed and deletes it. Returns an error if one occurs.
+func (c *FakeTOTPSeeds) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(totpseedsResource, c.ns, name, opts), &v1alpha1.TOTPSeed{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTOTPSeeds) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(totpseedsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TOTPSeedList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched tOTPSeed.
+func (c *FakeTOTPSeeds) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TOTPSeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(totpseedsResource, c.ns, name, pt, data, subresources...), &v1alpha1.TOTPSeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TOTPSeed), err
+}
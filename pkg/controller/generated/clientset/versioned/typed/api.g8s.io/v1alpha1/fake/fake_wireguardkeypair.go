@@ -0,0 +1,140 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWireGuardKeyPairs implements WireGuardKeyPairInterface
+type FakeWireGuardKeyPairs struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var wireguardkeypairsResource = v1alpha1.SchemeGroupVersion.WithResource("wireguardkeypairs")
+
+var wireguardkeypairsKind = v1alpha1.SchemeGroupVersion.WithKind("WireGuardKeyPair")
+
+// Get takes name of the wireGuardKeyPair, and returns the corresponding wireGuardKeyPair object, and an error if there is any.
+func (c *FakeWireGuardKeyPairs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(wireguardkeypairsResource, c.ns, name), &v1alpha1.WireGuardKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), err
+}
+
+// List takes label and field selectors, and returns the list of WireGuardKeyPairs that match those selectors.
+func (c *FakeWireGuardKeyPairs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.WireGuardKeyPairList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(wireguardkeypairsResource, wireguardkeypairsKind, c.ns, opts), &v1alpha1.WireGuardKeyPairList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.WireGuardKeyPairList{ListMeta: obj.(*v1alpha1.WireGuardKeyPairList).ListMeta}
+	for _, item := range obj.(*v1alpha1.WireGuardKeyPairList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested wireGuardKeyPairs.
+func (c *FakeWireGuardKeyPairs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(wireguardkeypairsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a wireGuardKeyPair and creates it.  Returns the server's representation of the wireGuardKeyPair, and an error, if there is any.
+func (c *FakeWireGuardKeyPairs) Create(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.CreateOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(wireguardkeypairsResource, c.ns, wireGuardKeyPair), &v1alpha1.WireGuardKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), err
+}
+
+// Update takes the representation of a wireGuardKeyPair and updates it. Returns the server's representation of the wireGuardKeyPair, and an error, if there is any.
+func (c *FakeWireGuardKeyPairs) Update(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(wireguardkeypairsResource, c.ns, wireGuardKeyPair), &v1alpha1.WireGuardKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeWireGuardKeyPairs) UpdateStatus(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (*v1alpha1.WireGuardKeyPair, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(wireguardkeypairsResource, "status", c.ns, wireGuardKeyPair), &v1alpha1.WireGuardKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), err
+}
+
+// Delete takes name of the wireGuardKeyPair and deletes it. Returns an error if one occurs.
+func (c *FakeWireGuardKeyPairs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(wireguardkeypairsResource, c.ns, name, opts), &v1alpha1.WireGuardKeyPair{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWireGuardKeyPairs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(wireguardkeypairsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.WireGuardKeyPairList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched wireGuardKeyPair.
+func (c *FakeWireGuardKeyPairs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WireGuardKeyPair, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(wireguardkeypairsResource, c.ns, name, pt, data, subresources...), &v1alpha1.WireGuardKeyPair{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), err
+}
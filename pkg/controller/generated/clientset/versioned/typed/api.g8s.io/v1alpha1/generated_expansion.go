@@ -17,10 +17,34 @@ limitations under the License.
 
 package v1alpha1
 
+type APITokenExpansion interface{}
+
+type AgeKeyPairExpansion interface{}
+
 type AllowlistExpansion interface{}
 
+type CertificateExpansion interface{}
+
+type CertificateAuthorityExpansion interface{}
+
+type DHParamsExpansion interface{}
+
+type JWTSigningKeyExpansion interface{}
+
 type LoginExpansion interface{}
 
+type PGPKeyPairExpansion interface{}
+
+type RandomSecretExpansion interface{}
+
+type RegistryCredentialExpansion interface{}
+
+type SSHCertificateAuthorityExpansion interface{}
+
 type SSHKeyPairExpansion interface{}
 
 type SelfSignedTLSBundleExpansion interface{}
+
+type TOTPSeedExpansion interface{}
+
+type WireGuardKeyPairExpansion interface{}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	scheme "github.com/jrodonnell/g8s/pkg/controller/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PGPKeyPairsGetter has a method to return a PGPKeyPairInterface.
+// A group's client should implement this interface.
+type PGPKeyPairsGetter interface {
+	PGPKeyPairs(namespace string) PGPKeyPairInterface
+}
+
+// PGPKeyPairInterface has methods to work with PGPKeyPair resources.
+type PGPKeyPairInterface interface {
+	Create(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.CreateOptions) (*v1alpha1.PGPKeyPair, error)
+	Update(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (*v1alpha1.PGPKeyPair, error)
+	UpdateStatus(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (*v1alpha1.PGPKeyPair, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.PGPKeyPair, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PGPKeyPairList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PGPKeyPair, err error)
+	PGPKeyPairExpansion
+}
+
+// pGPKeyPairs implements PGPKeyPairInterface
+type pGPKeyPairs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newPGPKeyPairs returns a PGPKeyPairs
+func newPGPKeyPairs(c *ApiV1alpha1Client, namespace string) *pGPKeyPairs {
+	return &pGPKeyPairs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the pGPKeyPair, and returns the corresponding pGPKeyPair object, and an error if there is any.
+func (c *pGPKeyPairs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	result = &v1alpha1.PGPKeyPair{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PGPKeyPairs that match those selectors.
+func (c *pGPKeyPairs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PGPKeyPairList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.PGPKeyPairList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested pGPKeyPairs.
+func (c *pGPKeyPairs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a pGPKeyPair and creates it.  Returns the server's representation of the pGPKeyPair, and an error, if there is any.
+func (c *pGPKeyPairs) Create(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.CreateOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	result = &v1alpha1.PGPKeyPair{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(pGPKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a pGPKeyPair and updates it. Returns the server's representation of the pGPKeyPair, and an error, if there is any.
+func (c *pGPKeyPairs) Update(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	result = &v1alpha1.PGPKeyPair{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		Name(pGPKeyPair.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(pGPKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *pGPKeyPairs) UpdateStatus(ctx context.Context, pGPKeyPair *v1alpha1.PGPKeyPair, opts v1.UpdateOptions) (result *v1alpha1.PGPKeyPair, err error) {
+	result = &v1alpha1.PGPKeyPair{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		Name(pGPKeyPair.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(pGPKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the pGPKeyPair and deletes it. Returns an error if one occurs.
+func (c *pGPKeyPairs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *pGPKeyPairs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched pGPKeyPair.
+func (c *pGPKeyPairs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PGPKeyPair, err error) {
+	result = &v1alpha1.PGPKeyPair{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("pgpkeypairs").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	scheme "github.com/jrodonnell/g8s/pkg/controller/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RandomSecretsGetter has a method to return a RandomSecretInterface.
+// A group's client should implement this interface.
+type RandomSecretsGetter interface {
+	RandomSecrets(namespace string) RandomSecretInterface
+}
+
+// RandomSecretInterface has methods to work with RandomSecret resources.
+type RandomSecretInterface interface {
+	Create(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.CreateOptions) (*v1alpha1.RandomSecret, error)
+	Update(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (*v1alpha1.RandomSecret, error)
+	UpdateStatus(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (*v1alpha1.RandomSecret, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.RandomSecret, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RandomSecretList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RandomSecret, err error)
+	RandomSecretExpansion
+}
+
+// randomSecrets implements RandomSecretInterface
+type randomSecrets struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRandomSecrets returns a RandomSecrets
+func newRandomSecrets(c *ApiV1alpha1Client, namespace string) *randomSecrets {
+	return &randomSecrets{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the randomSecret, and returns the corresponding randomSecret object, and an error if there is any.
+func (c *randomSecrets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RandomSecret, err error) {
+	result = &v1alpha1.RandomSecret{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RandomSecrets that match those selectors.
+func (c *randomSecrets) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RandomSecretList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.RandomSecretList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested randomSecrets.
+func (c *randomSecrets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a randomSecret and creates it.  Returns the server's representation of the randomSecret, and an error, if there is any.
+func (c *randomSecrets) Create(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.CreateOptions) (result *v1alpha1.RandomSecret, err error) {
+	result = &v1alpha1.RandomSecret{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(randomSecret).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a randomSecret and updates it. Returns the server's representation of the randomSecret, and an error, if there is any.
+func (c *randomSecrets) Update(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (result *v1alpha1.RandomSecret, err error) {
+	result = &v1alpha1.RandomSecret{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		Name(randomSecret.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(randomSecret).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *randomSecrets) UpdateStatus(ctx context.Context, randomSecret *v1alpha1.RandomSecret, opts v1.UpdateOptions) (result *v1alpha1.RandomSecret, err error) {
+	result = &v1alpha1.RandomSecret{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		Name(randomSecret.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(randomSecret).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the randomSecret and deletes it. Returns an error if one occurs.
+func (c *randomSecrets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *randomSecrets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched randomSecret.
+func (c *randomSecrets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RandomSecret, err error) {
+	result = &v1alpha1.RandomSecret{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("randomsecrets").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	scheme "github.com/jrodonnell/g8s/pkg/controller/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// WireGuardKeyPairsGetter has a method to return a WireGuardKeyPairInterface.
+// A group's client should implement this interface.
+type WireGuardKeyPairsGetter interface {
+	WireGuardKeyPairs(namespace string) WireGuardKeyPairInterface
+}
+
+// WireGuardKeyPairInterface has methods to work with WireGuardKeyPair resources.
+type WireGuardKeyPairInterface interface {
+	Create(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.CreateOptions) (*v1alpha1.WireGuardKeyPair, error)
+	Update(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (*v1alpha1.WireGuardKeyPair, error)
+	UpdateStatus(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (*v1alpha1.WireGuardKeyPair, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.WireGuardKeyPair, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WireGuardKeyPairList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WireGuardKeyPair, err error)
+	WireGuardKeyPairExpansion
+}
+
+// wireGuardKeyPairs implements WireGuardKeyPairInterface
+type wireGuardKeyPairs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newWireGuardKeyPairs returns a WireGuardKeyPairs
+func newWireGuardKeyPairs(c *ApiV1alpha1Client, namespace string) *wireGuardKeyPairs {
+	return &wireGuardKeyPairs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the wireGuardKeyPair, and returns the corresponding wireGuardKeyPair object, and an error if there is any.
+func (c *wireGuardKeyPairs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	result = &v1alpha1.WireGuardKeyPair{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of WireGuardKeyPairs that match those selectors.
+func (c *wireGuardKeyPairs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.WireGuardKeyPairList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.WireGuardKeyPairList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested wireGuardKeyPairs.
+func (c *wireGuardKeyPairs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a wireGuardKeyPair and creates it.  Returns the server's representation of the wireGuardKeyPair, and an error, if there is any.
+func (c *wireGuardKeyPairs) Create(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.CreateOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	result = &v1alpha1.WireGuardKeyPair{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(wireGuardKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a wireGuardKeyPair and updates it. Returns the server's representation of the wireGuardKeyPair, and an error, if there is any.
+func (c *wireGuardKeyPairs) Update(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	result = &v1alpha1.WireGuardKeyPair{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		Name(wireGuardKeyPair.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(wireGuardKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *wireGuardKeyPairs) UpdateStatus(ctx context.Context, wireGuardKeyPair *v1alpha1.WireGuardKeyPair, opts v1.UpdateOptions) (result *v1alpha1.WireGuardKeyPair, err error) {
+	result = &v1alpha1.WireGuardKeyPair{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		Name(wireGuardKeyPair.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(wireGuardKeyPair).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the wireGuardKeyPair and deletes it. Returns an error if one occurs.
+func (c *wireGuardKeyPairs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *wireGuardKeyPairs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched wireGuardKeyPair.
+func (c *wireGuardKeyPairs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WireGuardKeyPair, err error) {
+	result = &v1alpha1.WireGuardKeyPair{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("wireguardkeypairs").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	apig8siov1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	versioned "github.com/jrodonnell/g8s/pkg/controller/generated/clientset/versioned"
+	internalinterfaces "github.com/jrodonnell/g8s/pkg/controller/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/generated/listers/api.g8s.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AgeKeyPairInformer provides access to a shared informer and lister for
+// AgeKeyPairs.
+type AgeKeyPairInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.AgeKeyPairLister
+}
+
+type ageKeyPairInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAgeKeyPairInformer constructs a new informer for AgeKeyPair type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAgeKeyPairInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAgeKeyPairInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAgeKeyPairInformer constructs a new informer for AgeKeyPair type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAgeKeyPairInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ApiV1alpha1().AgeKeyPairs(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ApiV1alpha1().AgeKeyPairs(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apig8siov1alpha1.AgeKeyPair{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *ageKeyPairInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAgeKeyPairInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *ageKeyPairInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apig8siov1alpha1.AgeKeyPair{}, f.defaultInformer)
+}
+
+func (f *ageKeyPairInformer) Lister() v1alpha1.AgeKeyPairLister {
+	return v1alpha1.NewAgeKeyPairLister(f.Informer().GetIndexer())
+}
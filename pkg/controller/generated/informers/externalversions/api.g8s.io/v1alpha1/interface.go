@@ -23,14 +23,38 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// APITokens returns a APITokenInformer.
+	APITokens() APITokenInformer
+	// AgeKeyPairs returns a AgeKeyPairInformer.
+	AgeKeyPairs() AgeKeyPairInformer
 	// Allowlists returns a AllowlistInformer.
 	Allowlists() AllowlistInformer
+	// Certificates returns a CertificateInformer.
+	Certificates() CertificateInformer
+	// CertificateAuthorities returns a CertificateAuthorityInformer.
+	CertificateAuthorities() CertificateAuthorityInformer
+	// DHParams returns a DHParamsInformer.
+	DHParams() DHParamsInformer
+	// JWTSigningKeys returns a JWTSigningKeyInformer.
+	JWTSigningKeys() JWTSigningKeyInformer
 	// Logins returns a LoginInformer.
 	Logins() LoginInformer
+	// PGPKeyPairs returns a PGPKeyPairInformer.
+	PGPKeyPairs() PGPKeyPairInformer
+	// RandomSecrets returns a RandomSecretInformer.
+	RandomSecrets() RandomSecretInformer
+	// RegistryCredentials returns a RegistryCredentialInformer.
+	RegistryCredentials() RegistryCredentialInformer
+	// SSHCertificateAuthorities returns a SSHCertificateAuthorityInformer.
+	SSHCertificateAuthorities() SSHCertificateAuthorityInformer
 	// SSHKeyPairs returns a SSHKeyPairInformer.
 	SSHKeyPairs() SSHKeyPairInformer
 	// SelfSignedTLSBundles returns a SelfSignedTLSBundleInformer.
 	SelfSignedTLSBundles() SelfSignedTLSBundleInformer
+	// TOTPSeeds returns a TOTPSeedInformer.
+	TOTPSeeds() TOTPSeedInformer
+	// WireGuardKeyPairs returns a WireGuardKeyPairInformer.
+	WireGuardKeyPairs() WireGuardKeyPairInformer
 }
 
 type version struct {
@@ -44,16 +68,66 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// APITokens returns a APITokenInformer.
+func (v *version) APITokens() APITokenInformer {
+	return &aPITokenInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// AgeKeyPairs returns a AgeKeyPairInformer.
+func (v *version) AgeKeyPairs() AgeKeyPairInformer {
+	return &ageKeyPairInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Allowlists returns a AllowlistInformer.
 func (v *version) Allowlists() AllowlistInformer {
 	return &allowlistInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Certificates returns a CertificateInformer.
+func (v *version) Certificates() CertificateInformer {
+	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CertificateAuthorities returns a CertificateAuthorityInformer.
+func (v *version) CertificateAuthorities() CertificateAuthorityInformer {
+	return &certificateAuthorityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DHParams returns a DHParamsInformer.
+func (v *version) DHParams() DHParamsInformer {
+	return &dHParamsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// JWTSigningKeys returns a JWTSigningKeyInformer.
+func (v *version) JWTSigningKeys() JWTSigningKeyInformer {
+	return &jWTSigningKeyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Logins returns a LoginInformer.
 func (v *version) Logins() LoginInformer {
 	return &loginInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// PGPKeyPairs returns a PGPKeyPairInformer.
+func (v *version) PGPKeyPairs() PGPKeyPairInformer {
+	return &pGPKeyPairInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RandomSecrets returns a RandomSecretInformer.
+func (v *version) RandomSecrets() RandomSecretInformer {
+	return &randomSecretInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RegistryCredentials returns a RegistryCredentialInformer.
+func (v *version) RegistryCredentials() RegistryCredentialInformer {
+	return &registryCredentialInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SSHCertificateAuthorities returns a SSHCertificateAuthorityInformer.
+func (v *version) SSHCertificateAuthorities() SSHCertificateAuthorityInformer {
+	return &sSHCertificateAuthorityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SSHKeyPairs returns a SSHKeyPairInformer.
 func (v *version) SSHKeyPairs() SSHKeyPairInformer {
 	return &sSHKeyPairInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -63,3 +137,13 @@ func (v *version) SSHKeyPairs() SSHKeyPairInformer {
 func (v *version) SelfSignedTLSBundles() SelfSignedTLSBundleInformer {
 	return &selfSignedTLSBundleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TOTPSeeds returns a TOTPSeedInformer.
+func (v *version) TOTPSeeds() TOTPSeedInformer {
+	return &tOTPSeedInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// WireGuardKeyPairs returns a WireGuardKeyPairInformer.
+func (v *version) WireGuardKeyPairs() WireGuardKeyPairInformer {
+	return &wireGuardKeyPairInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
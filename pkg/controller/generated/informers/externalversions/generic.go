@@ -52,14 +52,38 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=api.g8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("apitokens"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().APITokens().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("agekeypairs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().AgeKeyPairs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("allowlists"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().Allowlists().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("certificates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().Certificates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("certificateauthorities"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().CertificateAuthorities().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dhparams"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().DHParams().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("jwtsigningkeys"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().JWTSigningKeys().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("logins"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().Logins().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("pgpkeypairs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().PGPKeyPairs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("randomsecrets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().RandomSecrets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("registrycredentials"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().RegistryCredentials().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sshcertificateauthorities"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().SSHCertificateAuthorities().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("sshkeypairs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().SSHKeyPairs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("selfsignedtlsbundles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().SelfSignedTLSBundles().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("totpseeds"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().TOTPSeeds().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("wireguardkeypairs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Api().V1alpha1().WireGuardKeyPairs().Informer()}, nil
 
 	}
 
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// AgeKeyPairLister helps list AgeKeyPairs.
+// All objects returned here must be treated as read-only.
+type AgeKeyPairLister interface {
+	// List lists all AgeKeyPairs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.AgeKeyPair, err error)
+	// AgeKeyPairs returns an object that can list and get AgeKeyPairs.
+	AgeKeyPairs(namespace string) AgeKeyPairNamespaceLister
+	AgeKeyPairListerExpansion
+}
+
+// ageKeyPairLister implements the AgeKeyPairLister interface.
+type ageKeyPairLister struct {
+	indexer cache.Indexer
+}
+
+// NewAgeKeyPairLister returns a new AgeKeyPairLister.
+func NewAgeKeyPairLister(indexer cache.Indexer) AgeKeyPairLister {
+	return &ageKeyPairLister{indexer: indexer}
+}
+
+// List lists all AgeKeyPairs in the indexer.
+func (s *ageKeyPairLister) List(selector labels.Selector) (ret []*v1alpha1.AgeKeyPair, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.AgeKeyPair))
+	})
+	return ret, err
+}
+
+// AgeKeyPairs returns an object that can list and get AgeKeyPairs.
+func (s *ageKeyPairLister) AgeKeyPairs(namespace string) AgeKeyPairNamespaceLister {
+	return ageKeyPairNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// AgeKeyPairNamespaceLister helps list and get AgeKeyPairs.
+// All objects returned here must be treated as read-only.
+type AgeKeyPairNamespaceLister interface {
+	// List lists all AgeKeyPairs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.AgeKeyPair, err error)
+	// Get retrieves the AgeKeyPair from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.AgeKeyPair, error)
+	AgeKeyPairNamespaceListerExpansion
+}
+
+// ageKeyPairNamespaceLister implements the AgeKeyPairNamespaceLister
+// interface.
+type ageKeyPairNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all AgeKeyPairs in the indexer for a given namespace.
+func (s ageKeyPairNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.AgeKeyPair, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.AgeKeyPair))
+	})
+	return ret, err
+}
+
+// Get retrieves the AgeKeyPair from the indexer for a given namespace and name.
+func (s ageKeyPairNamespaceLister) Get(name string) (*v1alpha1.AgeKeyPair, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("agekeypair"), name)
+	}
+	return obj.(*v1alpha1.AgeKeyPair), nil
+}
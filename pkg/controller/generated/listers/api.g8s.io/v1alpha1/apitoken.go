@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// APITokenLister helps list APITokens.
+// All objects returned here must be treated as read-only.
+type APITokenLister interface {
+	// List lists all APITokens in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.APIToken, err error)
+	// APITokens returns an object that can list and get APITokens.
+	APITokens(namespace string) APITokenNamespaceLister
+	APITokenListerExpansion
+}
+
+// aPITokenLister implements the APITokenLister interface.
+type aPITokenLister struct {
+	indexer cache.Indexer
+}
+
+// NewAPITokenLister returns a new APITokenLister.
+func NewAPITokenLister(indexer cache.Indexer) APITokenLister {
+	return &aPITokenLister{indexer: indexer}
+}
+
+// List lists all APITokens in the indexer.
+func (s *aPITokenLister) List(selector labels.Selector) (ret []*v1alpha1.APIToken, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.APIToken))
+	})
+	return ret, err
+}
+
+// APITokens returns an object that can list and get APITokens.
+func (s *aPITokenLister) APITokens(namespace string) APITokenNamespaceLister {
+	return aPITokenNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// APITokenNamespaceLister helps list and get APITokens.
+// All objects returned here must be treated as read-only.
+type APITokenNamespaceLister interface {
+	// List lists all APITokens in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.APIToken, err error)
+	// Get retrieves the APIToken from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.APIToken, error)
+	APITokenNamespaceListerExpansion
+}
+
+// aPITokenNamespaceLister implements the APITokenNamespaceLister
+// interface.
+type aPITokenNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all APITokens in the indexer for a given namespace.
+func (s aPITokenNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.APIToken, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.APIToken))
+	})
+	return ret, err
+}
+
+// Get retrieves the APIToken from the indexer for a given namespace and name.
+func (s aPITokenNamespaceLister) Get(name string) (*v1alpha1.APIToken, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("apitoken"), name)
+	}
+	return obj.(*v1alpha1.APIToken), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DHParamsLister helps list DHParams.
+// All objects returned here must be treated as read-only.
+type DHParamsLister interface {
+	// List lists all DHParams in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DHParams, err error)
+	// DHParams returns an object that can list and get DHParams.
+	DHParams(namespace string) DHParamsNamespaceLister
+	DHParamsListerExpansion
+}
+
+// dHParamsLister implements the DHParamsLister interface.
+type dHParamsLister struct {
+	indexer cache.Indexer
+}
+
+// NewDHParamsLister returns a new DHParamsLister.
+func NewDHParamsLister(indexer cache.Indexer) DHParamsLister {
+	return &dHParamsLister{indexer: indexer}
+}
+
+// List lists all DHParams in the indexer.
+func (s *dHParamsLister) List(selector labels.Selector) (ret []*v1alpha1.DHParams, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DHParams))
+	})
+	return ret, err
+}
+
+// DHParams returns an object that can list and get DHParams.
+func (s *dHParamsLister) DHParams(namespace string) DHParamsNamespaceLister {
+	return dHParamsNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DHParamsNamespaceLister helps list and get DHParams.
+// All objects returned here must be treated as read-only.
+type DHParamsNamespaceLister interface {
+	// List lists all DHParams in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DHParams, err error)
+	// Get retrieves the DHParams from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DHParams, error)
+	DHParamsNamespaceListerExpansion
+}
+
+// dHParamsNamespaceLister implements the DHParamsNamespaceLister
+// interface.
+type dHParamsNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DHParams in the indexer for a given namespace.
+func (s dHParamsNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DHParams, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DHParams))
+	})
+	return ret, err
+}
+
+// Get retrieves the DHParams from the indexer for a given namespace and name.
+func (s dHParamsNamespaceLister) Get(name string) (*v1alpha1.DHParams, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("dhparams"), name)
+	}
+	return obj.(*v1alpha1.DHParams), nil
+}
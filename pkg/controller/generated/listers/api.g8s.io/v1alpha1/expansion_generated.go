@@ -17,10 +17,58 @@ limitations under the License.
 
 package v1alpha1
 
+// APITokenListerExpansion allows custom methods to be added to
+// APITokenLister.
+type APITokenListerExpansion interface{}
+
+// APITokenNamespaceListerExpansion allows custom methods to be added to
+// APITokenNamespaceLister.
+type APITokenNamespaceListerExpansion interface{}
+
+// AgeKeyPairListerExpansion allows custom methods to be added to
+// AgeKeyPairLister.
+type AgeKeyPairListerExpansion interface{}
+
+// AgeKeyPairNamespaceListerExpansion allows custom methods to be added to
+// AgeKeyPairNamespaceLister.
+type AgeKeyPairNamespaceListerExpansion interface{}
+
 // AllowlistListerExpansion allows custom methods to be added to
 // AllowlistLister.
 type AllowlistListerExpansion interface{}
 
+// CertificateListerExpansion allows custom methods to be added to
+// CertificateLister.
+type CertificateListerExpansion interface{}
+
+// CertificateNamespaceListerExpansion allows custom methods to be added to
+// CertificateNamespaceLister.
+type CertificateNamespaceListerExpansion interface{}
+
+// CertificateAuthorityListerExpansion allows custom methods to be added to
+// CertificateAuthorityLister.
+type CertificateAuthorityListerExpansion interface{}
+
+// CertificateAuthorityNamespaceListerExpansion allows custom methods to be added to
+// CertificateAuthorityNamespaceLister.
+type CertificateAuthorityNamespaceListerExpansion interface{}
+
+// DHParamsListerExpansion allows custom methods to be added to
+// DHParamsLister.
+type DHParamsListerExpansion interface{}
+
+// DHParamsNamespaceListerExpansion allows custom methods to be added to
+// DHParamsNamespaceLister.
+type DHParamsNamespaceListerExpansion interface{}
+
+// JWTSigningKeyListerExpansion allows custom methods to be added to
+// JWTSigningKeyLister.
+type JWTSigningKeyListerExpansion interface{}
+
+// JWTSigningKeyNamespaceListerExpansion allows custom methods to be added to
+// JWTSigningKeyNamespaceLister.
+type JWTSigningKeyNamespaceListerExpansion interface{}
+
 // LoginListerExpansion allows custom methods to be added to
 // LoginLister.
 type LoginListerExpansion interface{}
@@ -29,6 +77,38 @@ type LoginListerExpansion interface{}
 // LoginNamespaceLister.
 type LoginNamespaceListerExpansion interface{}
 
+// PGPKeyPairListerExpansion allows custom methods to be added to
+// PGPKeyPairLister.
+type PGPKeyPairListerExpansion interface{}
+
+// PGPKeyPairNamespaceListerExpansion allows custom methods to be added to
+// PGPKeyPairNamespaceLister.
+type PGPKeyPairNamespaceListerExpansion interface{}
+
+// RandomSecretListerExpansion allows custom methods to be added to
+// RandomSecretLister.
+type RandomSecretListerExpansion interface{}
+
+// RandomSecretNamespaceListerExpansion allows custom methods to be added to
+// RandomSecretNamespaceLister.
+type RandomSecretNamespaceListerExpansion interface{}
+
+// RegistryCredentialListerExpansion allows custom methods to be added to
+// RegistryCredentialLister.
+type RegistryCredentialListerExpansion interface{}
+
+// RegistryCredentialNamespaceListerExpansion allows custom methods to be added to
+// RegistryCredentialNamespaceLister.
+type RegistryCredentialNamespaceListerExpansion interface{}
+
+// SSHCertificateAuthorityListerExpansion allows custom methods to be added to
+// SSHCertificateAuthorityLister.
+type SSHCertificateAuthorityListerExpansion interface{}
+
+// SSHCertificateAuthorityNamespaceListerExpansion allows custom methods to be added to
+// SSHCertificateAuthorityNamespaceLister.
+type SSHCertificateAuthorityNamespaceListerExpansion interface{}
+
 // SSHKeyPairListerExpansion allows custom methods to be added to
 // SSHKeyPairLister.
 type SSHKeyPairListerExpansion interface{}
@@ -44,3 +124,19 @@ type SelfSignedTLSBundleListerExpansion interface{}
 // SelfSignedTLSBundleNamespaceListerExpansion allows custom methods to be added to
 // SelfSignedTLSBundleNamespaceLister.
 type SelfSignedTLSBundleNamespaceListerExpansion interface{}
+
+// TOTPSeedListerExpansion allows custom methods to be added to
+// TOTPSeedLister.
+type TOTPSeedListerExpansion interface{}
+
+// TOTPSeedNamespaceListerExpansion allows custom methods to be added to
+// TOTPSeedNamespaceLister.
+type TOTPSeedNamespaceListerExpansion interface{}
+
+// WireGuardKeyPairListerExpansion allows custom methods to be added to
+// WireGuardKeyPairLister.
+type WireGuardKeyPairListerExpansion interface{}
+
+// WireGuardKeyPairNamespaceListerExpansion allows custom methods to be added to
+// WireGuardKeyPairNamespaceLister.
+type WireGuardKeyPairNamespaceListerExpansion interface{}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// JWTSigningKeyLister helps list JWTSigningKeys.
+// All objects returned here must be treated as read-only.
+type JWTSigningKeyLister interface {
+	// List lists all JWTSigningKeys in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.JWTSigningKey, err error)
+	// JWTSigningKeys returns an object that can list and get JWTSigningKeys.
+	JWTSigningKeys(namespace string) JWTSigningKeyNamespaceLister
+	JWTSigningKeyListerExpansion
+}
+
+// jWTSigningKeyLister implements the JWTSigningKeyLister interface.
+type jWTSigningKeyLister struct {
+	indexer cache.Indexer
+}
+
+// NewJWTSigningKeyLister returns a new JWTSigningKeyLister.
+func NewJWTSigningKeyLister(indexer cache.Indexer) JWTSigningKeyLister {
+	return &jWTSigningKeyLister{indexer: indexer}
+}
+
+// List lists all JWTSigningKeys in the indexer.
+func (s *jWTSigningKeyLister) List(selector labels.Selector) (ret []*v1alpha1.JWTSigningKey, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.JWTSigningKey))
+	})
+	return ret, err
+}
+
+// JWTSigningKeys returns an object that can list and get JWTSigningKeys.
+func (s *jWTSigningKeyLister) JWTSigningKeys(namespace string) JWTSigningKeyNamespaceLister {
+	return jWTSigningKeyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// JWTSigningKeyNamespaceLister helps list and get JWTSigningKeys.
+// All objects returned here must be treated as read-only.
+type JWTSigningKeyNamespaceLister interface {
+	// List lists all JWTSigningKeys in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.JWTSigningKey, err error)
+	// Get retrieves the JWTSigningKey from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.JWTSigningKey, error)
+	JWTSigningKeyNamespaceListerExpansion
+}
+
+// jWTSigningKeyNamespaceLister implements the JWTSigningKeyNamespaceLister
+// interface.
+type jWTSigningKeyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all JWTSigningKeys in the indexer for a given namespace.
+func (s jWTSigningKeyNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.JWTSigningKey, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.JWTSigningKey))
+	})
+	return ret, err
+}
+
+// Get retrieves the JWTSigningKey from the indexer for a given namespace and name.
+func (s jWTSigningKeyNamespaceLister) Get(name string) (*v1alpha1.JWTSigningKey, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("jwtsigningkey"), name)
+	}
+	return obj.(*v1alpha1.JWTSigningKey), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PGPKeyPairLister helps list PGPKeyPairs.
+// All objects returned here must be treated as read-only.
+type PGPKeyPairLister interface {
+	// List lists all PGPKeyPairs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PGPKeyPair, err error)
+	// PGPKeyPairs returns an object that can list and get PGPKeyPairs.
+	PGPKeyPairs(namespace string) PGPKeyPairNamespaceLister
+	PGPKeyPairListerExpansion
+}
+
+// pGPKeyPairLister implements the PGPKeyPairLister interface.
+type pGPKeyPairLister struct {
+	indexer cache.Indexer
+}
+
+// NewPGPKeyPairLister returns a new PGPKeyPairLister.
+func NewPGPKeyPairLister(indexer cache.Indexer) PGPKeyPairLister {
+	return &pGPKeyPairLister{indexer: indexer}
+}
+
+// List lists all PGPKeyPairs in the indexer.
+func (s *pGPKeyPairLister) List(selector labels.Selector) (ret []*v1alpha1.PGPKeyPair, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PGPKeyPair))
+	})
+	return ret, err
+}
+
+// PGPKeyPairs returns an object that can list and get PGPKeyPairs.
+func (s *pGPKeyPairLister) PGPKeyPairs(namespace string) PGPKeyPairNamespaceLister {
+	return pGPKeyPairNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PGPKeyPairNamespaceLister helps list and get PGPKeyPairs.
+// All objects returned here must be treated as read-only.
+type PGPKeyPairNamespaceLister interface {
+	// List lists all PGPKeyPairs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PGPKeyPair, err error)
+	// Get retrieves the PGPKeyPair from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.PGPKeyPair, error)
+	PGPKeyPairNamespaceListerExpansion
+}
+
+// pGPKeyPairNamespaceLister implements the PGPKeyPairNamespaceLister
+// interface.
+type pGPKeyPairNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all PGPKeyPairs in the indexer for a given namespace.
+func (s pGPKeyPairNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.PGPKeyPair, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PGPKeyPair))
+	})
+	return ret, err
+}
+
+// Get retrieves the PGPKeyPair from the indexer for a given namespace and name.
+func (s pGPKeyPairNamespaceLister) Get(name string) (*v1alpha1.PGPKeyPair, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("pgpkeypair"), name)
+	}
+	return obj.(*v1alpha1.PGPKeyPair), nil
+}
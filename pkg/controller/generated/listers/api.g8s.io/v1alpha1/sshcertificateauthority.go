@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SSHCertificateAuthorityLister helps list SSHCertificateAuthorities.
+// All objects returned here must be treated as read-only.
+type SSHCertificateAuthorityLister interface {
+	// List lists all SSHCertificateAuthorities in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.SSHCertificateAuthority, err error)
+	// SSHCertificateAuthorities returns an object that can list and get SSHCertificateAuthorities.
+	SSHCertificateAuthorities(namespace string) SSHCertificateAuthorityNamespaceLister
+	SSHCertificateAuthorityListerExpansion
+}
+
+// sSHCertificateAuthorityLister implements the SSHCertificateAuthorityLister interface.
+type sSHCertificateAuthorityLister struct {
+	indexer cache.Indexer
+}
+
+// NewSSHCertificateAuthorityLister returns a new SSHCertificateAuthorityLister.
+func NewSSHCertificateAuthorityLister(indexer cache.Indexer) SSHCertificateAuthorityLister {
+	return &sSHCertificateAuthorityLister{indexer: indexer}
+}
+
+// List lists all SSHCertificateAuthorities in the indexer.
+func (s *sSHCertificateAuthorityLister) List(selector labels.Selector) (ret []*v1alpha1.SSHCertificateAuthority, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.SSHCertificateAuthority))
+	})
+	return ret, err
+}
+
+// SSHCertificateAuthorities returns an object that can list and get SSHCertificateAuthorities.
+func (s *sSHCertificateAuthorityLister) SSHCertificateAuthorities(namespace string) SSHCertificateAuthorityNamespaceLister {
+	return sSHCertificateAuthorityNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SSHCertificateAuthorityNamespaceLister helps list and get SSHCertificateAuthorities.
+// All objects returned here must be treated as read-only.
+type SSHCertificateAuthorityNamespaceLister interface {
+	// List lists all SSHCertificateAuthorities in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.SSHCertificateAuthority, err error)
+	// Get retrieves the SSHCertificateAuthority from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.SSHCertificateAuthority, error)
+	SSHCertificateAuthorityNamespaceListerExpansion
+}
+
+// sSHCertificateAuthorityNamespaceLister implements the SSHCertificateAuthorityNamespaceLister
+// interface.
+type sSHCertificateAuthorityNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SSHCertificateAuthorities in the indexer for a given namespace.
+func (s sSHCertificateAuthorityNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.SSHCertificateAuthority, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.SSHCertificateAuthority))
+	})
+	return ret, err
+}
+
+// Get retrieves the SSHCertificateAuthority from the indexer for a given namespace and name.
+func (s sSHCertificateAuthorityNamespaceLister) Get(name string) (*v1alpha1.SSHCertificateAuthority, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("sshcertificateauthority"), name)
+	}
+	return obj.(*v1alpha1.SSHCertificateAuthority), nil
+}
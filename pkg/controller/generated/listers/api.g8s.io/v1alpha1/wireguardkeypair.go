@@ -0,0 +1,98 @@
+/*
+Copyright 2024 James Riley O'Donnell.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WireGuardKeyPairLister helps list WireGuardKeyPairs.
+// All objects returned here must be treated as read-only.
+type WireGuardKeyPairLister interface {
+	// List lists all WireGuardKeyPairs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.WireGuardKeyPair, err error)
+	// WireGuardKeyPairs returns an object that can list and get WireGuardKeyPairs.
+	WireGuardKeyPairs(namespace string) WireGuardKeyPairNamespaceLister
+	WireGuardKeyPairListerExpansion
+}
+
+// wireGuardKeyPairLister implements the WireGuardKeyPairLister interface.
+type wireGuardKeyPairLister struct {
+	indexer cache.Indexer
+}
+
+// NewWireGuardKeyPairLister returns a new WireGuardKeyPairLister.
+func NewWireGuardKeyPairLister(indexer cache.Indexer) WireGuardKeyPairLister {
+	return &wireGuardKeyPairLister{indexer: indexer}
+}
+
+// List lists all WireGuardKeyPairs in the indexer.
+func (s *wireGuardKeyPairLister) List(selector labels.Selector) (ret []*v1alpha1.WireGuardKeyPair, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.WireGuardKeyPair))
+	})
+	return ret, err
+}
+
+// WireGuardKeyPairs returns an object that can list and get WireGuardKeyPairs.
+func (s *wireGuardKeyPairLister) WireGuardKeyPairs(namespace string) WireGuardKeyPairNamespaceLister {
+	return wireGuardKeyPairNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// WireGuardKeyPairNamespaceLister helps list and get WireGuardKeyPairs.
+// All objects returned here must be treated as read-only.
+type WireGuardKeyPairNamespaceLister interface {
+	// List lists all WireGuardKeyPairs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.WireGuardKeyPair, err error)
+	// Get retrieves the WireGuardKeyPair from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.WireGuardKeyPair, error)
+	WireGuardKeyPairNamespaceListerExpansion
+}
+
+// wireGuardKeyPairNamespaceLister implements the WireGuardKeyPairNamespaceLister
+// interface.
+type wireGuardKeyPairNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all WireGuardKeyPairs in the indexer for a given namespace.
+func (s wireGuardKeyPairNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.WireGuardKeyPair, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.WireGuardKeyPair))
+	})
+	return ret, err
+}
+
+// Get retrieves the WireGuardKeyPair from the indexer for a given namespace and name.
+func (s wireGuardKeyPairNamespaceLister) Get(name string) (*v1alpha1.WireGuardKeyPair, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("wireguardkeypair"), name)
+	}
+	return obj.(*v1alpha1.WireGuardKeyPair), nil
+}
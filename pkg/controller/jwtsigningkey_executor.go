@@ -0,0 +1,385 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runJWTSigningKeyWorker is a long-running function that will continually call the
+// processNextJWTSigningKeyWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runJWTSigningKeyWorker(ctx context.Context) {
+	for c.processNextJWTSigningKeyWorkItem(ctx) {
+	}
+}
+
+// processNextJWTSigningKeyWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the jwtSigningKeySyncHandler.
+func (c *Controller) processNextJWTSigningKeyWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.jwtSigningKeyWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.jwtSigningKeyWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.jwtSigningKeyWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.jwtSigningKeyWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the jwtSigningKeySyncHandler, passing it the namespace/name string of the
+		// JWTSigningKey resource to be synced.
+		if err := c.jwtSigningKeySyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.jwtSigningKeyWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.jwtSigningKeyWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// jwtSigningKeySyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the JWTSigningKey resource
+// with the current status of the resource.
+func (c *Controller) jwtSigningKeySyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the JWTSigningKey resource with this namespace/name
+	jwtSigningKeyFromLister, err := c.jwtSigningKeyLister.JWTSigningKeys(namespace).Get(name)
+	if err != nil {
+		// The JWTSigningKey resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("JWTSigningKey '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	jwtSigningKey := jwtSigningKeyFromLister.DeepCopy()
+
+	backendName := "jwtsigningkey-" + jwtSigningKey.ObjectMeta.Name
+	historyName := "jwtsigningkey-" + jwtSigningKey.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(jwtSigningKey.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, jwtSigningKey.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sJWTSigningKey := internalv1alpha1.NewJWTSigningKey(jwtSigningKey)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sJWTSigningKey.Validate(); err != nil {
+		c.recorder.Event(jwtSigningKey, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sJWTSigningKey.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(jwtSigningKey, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sJWTSigningKey.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(jwtSigningKey.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sJWTSigningKey, backendContent, jwtSigningKeySecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(jwtSigningKey.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sJWTSigningKey, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sJWTSigningKey.BackendContent(internalv1alpha1.StringData(history.Data), jwtSigningKey.Status.LiveGeneration)
+		if content == nil {
+			content = g8sJWTSigningKey.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(jwtSigningKey.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sJWTSigningKey, content, jwtSigningKeySecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["key.pem-0"] = string(backend.Data["key.pem"])
+		content["kid-0"] = string(backend.Data["kid"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(jwtSigningKey, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(jwtSigningKey.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sJWTSigningKey, content), metav1.CreateOptions{})
+		jwtSigningKey.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this JWTSigningKey resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, jwtSigningKey) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(jwtSigningKey, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, jwtSigningKey) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(jwtSigningKey, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the JWTSigningKey ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     jwtSigningKey,
+		g8s:        g8sJWTSigningKey,
+		workqueue:  c.jwtSigningKeyWorkqueue,
+		rotation:   jwtSigningKey.Spec.Rotation,
+		history:    jwtSigningKey.Spec.History,
+		status:     &jwtSigningKey.Status.RotationStatus,
+		secretType: jwtSigningKeySecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().JWTSigningKeys(jwtSigningKey.Namespace).UpdateStatus(ctx, jwtSigningKey, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*jwtSigningKey = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Publish every key that tokens may still be signed by, in the backend Secret as
+	// well since pruning and rollbacks change the JWKS without replacing it
+	jwks := internalv1alpha1.JWKS(internalv1alpha1.StringData(history.Data))
+	if string(backend.Data["jwks.json"]) != jwks {
+		content := g8sJWTSigningKey.BackendContent(internalv1alpha1.StringData(history.Data), jwtSigningKey.Status.LiveGeneration)
+		if content != nil {
+			backend, err = c.replaceBackend(ctx, g8sJWTSigningKey, content, jwtSigningKeySecretType)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = c.syncJWKS(ctx, jwtSigningKey, g8sJWTSigningKey, history, jwks)
+	if err != nil {
+		return err
+	}
+
+	jwtSigningKey.Status.KeyID = string(backend.Data["kid"])
+
+	// Finally, we update the status block of the JWTSigningKey resource to reflect the
+	// current state of the world
+	err = c.updateJWTSigningKeyStatus(jwtSigningKey)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(jwtSigningKey, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// jwtSigningKeySecretType is the type of a JWTSigningKey's backend Secret
+const jwtSigningKeySecretType corev1.SecretType = "g8s.io/jwt-signing-key"
+
+// syncJWKS publishes jwks in the JWKS Secret of jwtSigningKey, replacing the Secret
+// whenever it changes. This is the Secret Allowlists propagate, the backend Secret
+// holds the signing key.
+func (c *Controller) syncJWKS(ctx context.Context, jwtSigningKey *g8sv1alpha1.JWTSigningKey, g8sJWTSigningKey *internalv1alpha1.JWTSigningKey, history *corev1.Secret, jwks string) error {
+	logger := klog.FromContext(ctx)
+	name := "jwtsigningkey-" + jwtSigningKey.Name + "-jwks"
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(jwtSigningKey.Namespace)
+
+	published, err := c.secretLister.Secrets(jwtSigningKey.Namespace).Get(name)
+	if err == nil {
+		if !metav1.IsControlledBy(published, jwtSigningKey) {
+			msg := fmt.Sprintf(MessageResourceExists, published.Name)
+			c.recorder.Event(jwtSigningKey, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if string(published.Data["jwks.json"]) == jwks {
+			return nil
+		}
+
+		// immutable like the backend Secret, so it has to be replaced rather than updated
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	logger.V(4).Info("Publish JWKS Secret resource", "name", name)
+	_, err = secrets.Create(ctx, internalv1alpha1.NewJWKSSecret(g8sJWTSigningKey, internalv1alpha1.StringData(history.Data)), metav1.CreateOptions{})
+	return err
+}
+
+func (c *Controller) updateJWTSigningKeyStatus(jwtSigningKey *g8sv1alpha1.JWTSigningKey) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	jwtSigningKeyCopy := jwtSigningKey.DeepCopy()
+	jwtSigningKeyCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the JWTSigningKey resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().JWTSigningKeys(jwtSigningKey.Namespace).UpdateStatus(context.TODO(), jwtSigningKeyCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueJWTSigningKey takes a JWTSigningKey resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha JWTSigningKey.
+func (c *Controller) enqueueJWTSigningKey(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.jwtSigningKeyWorkqueue.Add(key)
+}
+
+// handleJWTSigningKeyObject will take any resource implementing metav1.Object and attempt
+// to find the JWTSigningKey resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that JWTSigningKey resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleJWTSigningKeyObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a JWTSigningKey, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "JWTSigningKey" {
+			return
+		}
+
+		jwtSigningKey, err := c.jwtSigningKeyLister.JWTSigningKeys(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "jwtSigningKey", ownerRef.Name)
+			return
+		}
+
+		c.enqueueJWTSigningKey(jwtSigningKey)
+		return
+	}
+}
+
+// Set up an event handler for when JWTSigningKey and/or their backend and history Secret resources change
+func (c *Controller) setJWTSigningKeyInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.jwtSigningKeyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueJWTSigningKey,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueJWTSigningKey(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			jwt, ok := obj.(*g8sv1alpha1.JWTSigningKey)
+			if !ok {
+				logger.Error(nil, "obj is not a JWTSigningKey")
+			}
+			c.recorder.Event(jwt, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when JWTSigningKey backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a JWTSigningKey resource then the handler will enqueue that JWTSigningKey resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleJWTSigningKeyObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleJWTSigningKeyObject(new)
+		},
+		DeleteFunc: c.handleJWTSigningKeyObject,
+	})
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -114,21 +115,34 @@ func (c *Controller) loginSyncHandler(ctx context.Context, key string) error {
 
 	// Get the backend Secret and history Secret with this namespace/name
 	backendFromLister, berr := c.secretLister.Secrets(login.Namespace).Get(backendName)
-	historyFromLister, herr := c.secretLister.Secrets(login.Namespace).Get(historyName)
+	historyFromLister, herr := c.getHistory(ctx, login.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
 
 	// DeepCopy for safety
 	backend := backendFromLister.DeepCopy()
 	history := historyFromLister.DeepCopy()
 
-	g8sLogin := *internalv1alpha1.NewLogin(login)
+	g8sLogin := internalv1alpha1.NewLogin(login)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sLogin.Validate(); err != nil {
+		c.recorder.Event(login, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
 
 	// If the backend and history resources don't exist, create them
 	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
 		logger.V(4).Info("Create backend and history Secret resources")
-		historyContent := g8sLogin.Rotate()
-		backendContent := make(map[string]string)
-		backendContent["username"] = g8sLogin.Spec.Username
-		backendContent["password"] = historyContent["password-0"]
+		var historyContent map[string]string
+		historyContent, err = g8sLogin.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(login, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sLogin.BackendContent(historyContent, 0)
 
 		backend, err = c.Client.kubeClientset.CoreV1().Secrets(login.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sLogin, backendContent, "kubernetes.io/basic-auth"), metav1.CreateOptions{})
 		if err != nil {
@@ -137,15 +151,23 @@ func (c *Controller) loginSyncHandler(ctx context.Context, key string) error {
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(login.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sLogin, historyContent), metav1.CreateOptions{})
 	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
 		logger.V(4).Info("Create backend Secret resources from history")
-		content := make(map[string]string)
-		content["username"] = login.Spec.Username
-		content["password"] = string(history.Data["password-0"])
+		content := g8sLogin.BackendContent(internalv1alpha1.StringData(history.Data), login.Status.LiveGeneration)
+		if content == nil {
+			content = g8sLogin.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
 		backend, err = c.Client.kubeClientset.CoreV1().Secrets(login.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sLogin, content, "kubernetes.io/basic-auth"), metav1.CreateOptions{})
 	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
 		logger.V(4).Info("Create history Secret resources from backend")
 		content := make(map[string]string)
 		content["password-0"] = string(backend.Data["password"])
+		for _, k := range internalv1alpha1.PasswordHashFields {
+			if v, ok := backend.Data[k]; ok {
+				content[k+"-0"] = string(v)
+			}
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(login, internalv1alpha1.ReasonRebuilt, ""))
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(login.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sLogin, content), metav1.CreateOptions{})
+		login.Status.LiveGeneration = 0
 	} else {
 		logger.V(4).Info("Secret resources for history and backend exist")
 	}
@@ -169,6 +191,64 @@ func (c *Controller) loginSyncHandler(ctx context.Context, key string) error {
 		return fmt.Errorf("%s", msg)
 	}
 
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the Login ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     login,
+		g8s:        g8sLogin,
+		workqueue:  c.loginWorkqueue,
+		rotation:   login.Spec.Rotation,
+		history:    login.Spec.History,
+		status:     &login.Status.RotationStatus,
+		secretType: "kubernetes.io/basic-auth",
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().Logins(login.Namespace).UpdateStatus(ctx, login, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*login = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Recreate the backend Secret if spec.password.hashes changed, or the live
+	// generation was rolled back to is from before it did. Hashes the live generation
+	// doesn't have are computed once and written to the history first, so that the
+	// backend Secret is always rebuilt from the same ones.
+	if g8sLogin.HashesDrifted(backend.Data) {
+		historyContent, added, err := g8sLogin.CompleteHashes(internalv1alpha1.StringData(history.Data), login.Status.LiveGeneration)
+		if err != nil {
+			return err
+		}
+		if added {
+			logger.V(4).Info("Add password hashes to history Secret resource")
+			history, err = c.swapHistory(ctx, internalv1alpha1.NewHistorySecret(g8sLogin, historyContent))
+			if err != nil {
+				return err
+			}
+		}
+
+		content := g8sLogin.BackendContent(historyContent, login.Status.LiveGeneration)
+		if content != nil {
+			logger.V(4).Info("Recreate backend Secret resource with current password hashes")
+			backend, err = c.replaceBackend(ctx, g8sLogin, content, "kubernetes.io/basic-auth")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Publish the hashes of the live generation, which rotations and rollbacks change
+	err = c.syncLoginHashes(ctx, login, g8sLogin, backend)
+	if err != nil {
+		return err
+	}
+
 	// Finally, we update the status block of the Login resource to reflect the
 	// current state of the world
 	err = c.updateLoginStatus(login)
@@ -180,6 +260,45 @@ func (c *Controller) loginSyncHandler(ctx context.Context, key string) error {
 	return nil
 }
 
+// syncLoginHashes publishes the username and password hashes in backend, the backend
+// Secret of login, in its hashes Secret, replacing the Secret whenever they change and
+// deleting it once spec.password.hashes is empty. This is the Secret Allowlists
+// propagate through loginHashes, the backend Secret holds the password.
+func (c *Controller) syncLoginHashes(ctx context.Context, login *g8sv1alpha1.Login, g8sLogin *internalv1alpha1.Login, backend *corev1.Secret) error {
+	logger := klog.FromContext(ctx)
+	name := "login-" + login.Name + "-hashes"
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(login.Namespace)
+	hashes := internalv1alpha1.NewLoginHashesSecret(g8sLogin, internalv1alpha1.StringData(backend.Data))
+
+	published, err := c.secretLister.Secrets(login.Namespace).Get(name)
+	if err == nil {
+		if !metav1.IsControlledBy(published, login) {
+			msg := fmt.Sprintf(MessageResourceExists, published.Name)
+			c.recorder.Event(login, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if len(hashes.StringData) > 1 && maps.Equal(internalv1alpha1.StringData(published.Data), hashes.StringData) {
+			return nil
+		}
+
+		// immutable like the backend Secret, so it has to be replaced rather than updated
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	// the username alone isn't worth publishing
+	if len(hashes.StringData) == 1 {
+		return nil
+	}
+	logger.V(4).Info("Publish hashes Secret resource", "name", name)
+	_, err = secrets.Create(ctx, hashes, metav1.CreateOptions{})
+	return err
+}
+
 func (c *Controller) updateLoginStatus(login *g8sv1alpha1.Login) error {
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	// You can use DeepCopy() to make a deep copy of original object and modify this copy
@@ -0,0 +1,370 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runPGPKeyPairWorker is a long-running function that will continually call the
+// processNextPGPKeyPairWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runPGPKeyPairWorker(ctx context.Context) {
+	for c.processNextPGPKeyPairWorkItem(ctx) {
+	}
+}
+
+// processNextPGPKeyPairWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the pgpKeyPairSyncHandler.
+func (c *Controller) processNextPGPKeyPairWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.pgpKeyPairWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.pgpKeyPairWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.pgpKeyPairWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.pgpKeyPairWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the pgpKeyPairSyncHandler, passing it the namespace/name string of the
+		// PGPKeyPair resource to be synced.
+		if err := c.pgpKeyPairSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.pgpKeyPairWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.pgpKeyPairWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// pgpKeyPairSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the PGPKeyPair resource
+// with the current status of the resource.
+func (c *Controller) pgpKeyPairSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the PGPKeyPair resource with this namespace/name
+	pgpKeyPairFromLister, err := c.pgpKeyPairLister.PGPKeyPairs(namespace).Get(name)
+	if err != nil {
+		// The PGPKeyPair resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("PGPKeyPair '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	pgpKeyPair := pgpKeyPairFromLister.DeepCopy()
+
+	backendName := "pgpkeypair-" + pgpKeyPair.ObjectMeta.Name
+	historyName := "pgpkeypair-" + pgpKeyPair.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(pgpKeyPair.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, pgpKeyPair.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sPGPKeyPair := internalv1alpha1.NewPGPKeyPair(pgpKeyPair)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sPGPKeyPair.Validate(); err != nil {
+		c.recorder.Event(pgpKeyPair, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sPGPKeyPair.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(pgpKeyPair, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sPGPKeyPair.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(pgpKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sPGPKeyPair, backendContent, pgpKeyPairSecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(pgpKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sPGPKeyPair, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sPGPKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), pgpKeyPair.Status.LiveGeneration)
+		if content == nil {
+			content = g8sPGPKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(pgpKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sPGPKeyPair, content, pgpKeyPairSecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["private.asc-0"] = string(backend.Data["private.asc"])
+		content["public.asc-0"] = string(backend.Data["public.asc"])
+		if passphrase, ok := backend.Data["passphrase"]; ok {
+			content["passphrase-0"] = string(passphrase)
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(pgpKeyPair, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(pgpKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sPGPKeyPair, content), metav1.CreateOptions{})
+		pgpKeyPair.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this PGPKeyPair resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, pgpKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(pgpKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, pgpKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(pgpKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the PGPKeyPair ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     pgpKeyPair,
+		g8s:        g8sPGPKeyPair,
+		workqueue:  c.pgpKeyPairWorkqueue,
+		rotation:   pgpKeyPair.Spec.Rotation,
+		history:    pgpKeyPair.Spec.History,
+		status:     &pgpKeyPair.Status.RotationStatus,
+		secretType: pgpKeyPairSecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().PGPKeyPairs(pgpKeyPair.Namespace).UpdateStatus(ctx, pgpKeyPair, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*pgpKeyPair = *updated
+			return nil
+		},
+	}
+	rotation := c.checkRotation(rotated, backend)
+
+	// Renew the key in the backend Secret once it's within renewBefore of expiring,
+	// right away if it can't be parsed. Unlike a rotation this keeps the key and only
+	// extends its expiry.
+	renew := false
+	expiry, err := internalv1alpha1.PGPExpiry(backend.Data["public.asc"])
+	if err != nil {
+		logger.V(4).Info("Cannot parse key in backend Secret, renewing", "err", err.Error())
+		renew = true
+	} else if expiry != nil && !pgpRenewalTime(*expiry, g8sPGPKeyPair.RenewBefore()).After(time.Now()) {
+		renew = true
+	}
+
+	var renewKey *renewal
+	if renew {
+		renewKey = &renewal{
+			generate: func() (map[string]string, error) { return g8sPGPKeyPair.Renew() },
+			message:  MessageCertificateRenewed,
+		}
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, renewKey)
+	if err != nil {
+		return err
+	}
+
+	// Record the fingerprint and expiry of the key now in the backend Secret and come
+	// back when it's due for renewal
+	pgpKeyPair.Status.Fingerprint = internalv1alpha1.PGPFingerprint(backend.Data["public.asc"])
+	pgpKeyPair.Status.ExpiresAt = nil
+	pgpKeyPair.Status.RenewalTime = nil
+	if expiry, err := internalv1alpha1.PGPExpiry(backend.Data["public.asc"]); err == nil && expiry != nil {
+		renewal := pgpRenewalTime(*expiry, g8sPGPKeyPair.RenewBefore())
+		pgpKeyPair.Status.ExpiresAt = &metav1.Time{Time: *expiry}
+		pgpKeyPair.Status.RenewalTime = &metav1.Time{Time: renewal}
+		c.pgpKeyPairWorkqueue.AddAfter(key, time.Until(renewal))
+	}
+
+	// Finally, we update the status block of the PGPKeyPair resource to reflect the
+	// current state of the world
+	err = c.updatePGPKeyPairStatus(pgpKeyPair)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(pgpKeyPair, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// pgpKeyPairSecretType is the type of a PGPKeyPair's backend Secret
+const pgpKeyPairSecretType corev1.SecretType = "g8s.io/pgp-key-pair"
+
+func (c *Controller) updatePGPKeyPairStatus(pgpKeyPair *g8sv1alpha1.PGPKeyPair) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	pgpKeyPairCopy := pgpKeyPair.DeepCopy()
+	pgpKeyPairCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the PGPKeyPair resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().PGPKeyPairs(pgpKeyPair.Namespace).UpdateStatus(context.TODO(), pgpKeyPairCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueuePGPKeyPair takes a PGPKeyPair resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha PGPKeyPair.
+func (c *Controller) enqueuePGPKeyPair(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.pgpKeyPairWorkqueue.Add(key)
+}
+
+// handlePGPKeyPairObject will take any resource implementing metav1.Object and attempt
+// to find the PGPKeyPair resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that PGPKeyPair resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handlePGPKeyPairObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a PGPKeyPair, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "PGPKeyPair" {
+			return
+		}
+
+		pgpKeyPair, err := c.pgpKeyPairLister.PGPKeyPairs(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "pgpKeyPair", ownerRef.Name)
+			return
+		}
+
+		c.enqueuePGPKeyPair(pgpKeyPair)
+		return
+	}
+}
+
+// Set up an event handler for when PGPKeyPair and/or their backend and history Secret resources change
+func (c *Controller) setPGPKeyPairInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.pgpKeyPairInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueuePGPKeyPair,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueuePGPKeyPair(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pgp, ok := obj.(*g8sv1alpha1.PGPKeyPair)
+			if !ok {
+				logger.Error(nil, "obj is not a PGPKeyPair")
+			}
+			c.recorder.Event(pgp, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when PGPKeyPair backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a PGPKeyPair resource then the handler will enqueue that PGPKeyPair resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handlePGPKeyPairObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handlePGPKeyPairObject(new)
+		},
+		DeleteFunc: c.handlePGPKeyPairObject,
+	})
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// parseCertificate parses the first PEM encoded cert in data
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// renewalTime returns when a cert should be reissued, renewBefore ahead of its expiry
+func renewalTime(cert *x509.Certificate, renewBefore time.Duration) time.Time {
+	return cert.NotAfter.Add(-renewBefore)
+}
+
+// annotationsDrifted reports whether the annotations of secret starting with prefix
+// differ from want
+func annotationsDrifted(secret *corev1.Secret, prefix string, want map[string]string) bool {
+	have := 0
+	for k, v := range secret.Annotations {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if want[k] != v {
+			return true
+		}
+		have++
+	}
+	return have != len(want)
+}
+
+// webhookTLSBundle is the SelfSignedTLSBundle in the g8s namespace that secures the
+// g8s webhook, its CA has to be trusted by the webhook configurations
+const webhookTLSBundle = "g8s-webhook"
+
+// syncWebhookCABundle sets the caBundle of the g8s-webhook Mutating and
+// ValidatingWebhookConfigurations to caPEM wherever it differs, so that the API server
+// keeps trusting the webhook after its CA is reissued.
+func (c *Controller) syncWebhookCABundle(ctx context.Context, caPEM []byte) error {
+	logger := klog.FromContext(ctx)
+	admission := c.Client.kubeClientset.AdmissionregistrationV1()
+
+	mutating, err := admission.MutatingWebhookConfigurations().Get(ctx, webhookTLSBundle, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		var patch []string
+		for i, w := range mutating.Webhooks {
+			if !bytes.Equal(w.ClientConfig.CABundle, caPEM) {
+				patch = append(patch, caBundlePatchOp(i, caPEM))
+			}
+		}
+		if len(patch) > 0 {
+			logger.V(4).Info("Update caBundle of MutatingWebhookConfiguration", "name", webhookTLSBundle)
+			_, err = admission.MutatingWebhookConfigurations().Patch(ctx, webhookTLSBundle, types.JSONPatchType, []byte("["+strings.Join(patch, ",")+"]"), metav1.PatchOptions{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	validating, err := admission.ValidatingWebhookConfigurations().Get(ctx, webhookTLSBundle, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		var patch []string
+		for i, w := range validating.Webhooks {
+			if !bytes.Equal(w.ClientConfig.CABundle, caPEM) {
+				patch = append(patch, caBundlePatchOp(i, caPEM))
+			}
+		}
+		if len(patch) > 0 {
+			logger.V(4).Info("Update caBundle of ValidatingWebhookConfiguration", "name", webhookTLSBundle)
+			_, err = admission.ValidatingWebhookConfigurations().Patch(ctx, webhookTLSBundle, types.JSONPatchType, []byte("["+strings.Join(patch, ",")+"]"), metav1.PatchOptions{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// caBundlePatchOp returns a JSONPatch operation setting the caBundle of webhook i
+func caBundlePatchOp(i int, caPEM []byte) string {
+	return fmt.Sprintf(`{"op":"add","path":"/webhooks/%d/clientConfig/caBundle","value":%q}`, i, base64.StdEncoding.EncodeToString(caPEM))
+}
+
+// sshRenewalTime returns when an SSH certificate should be reissued, renewBefore
+// ahead of its expiry
+func sshRenewalTime(cert *ssh.Certificate, renewBefore time.Duration) time.Time {
+	return time.Unix(int64(cert.ValidBefore), 0).Add(-renewBefore)
+}
+
+// pgpRenewalTime returns when a PGP key should be renewed, renewBefore ahead of its
+// expiry
+func pgpRenewalTime(expiry time.Time, renewBefore time.Duration) time.Time {
+	return expiry.Add(-renewBefore)
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+)
+
+// workload identifies a Deployment, StatefulSet or DaemonSet by kind and name
+type workload struct {
+	kind string
+	name string
+}
+
+func (w workload) String() string {
+	return strings.ToLower(w.kind) + "/" + w.name
+}
+
+// restartWorkloads triggers a rolling restart of every Deployment, StatefulSet and
+// DaemonSet in namespace with Pods that were mutated by the webhook following
+// allowlist and consume one of secretnames. Env vars from Secrets never refresh in
+// a running Pod, so this is the only way for them to pick up a changed Secret.
+// Workloads annotated with g8s.io/skip-restart=true are left alone. Failures are
+// reported as Events rather than returned, since the Secrets were already replaced
+// and requeueing wouldn't retry the restart.
+func (c *Controller) restartWorkloads(ctx context.Context, allowlist *g8sv1alpha1.Allowlist, namespace string, secretnames []string) {
+	logger := klog.FromContext(ctx)
+	pods, err := c.podLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error listing Pods in namespace '%s': %s", namespace, err.Error()))
+		return
+	}
+
+	var workloads []workload
+	for _, pod := range pods {
+		if pod.Annotations[g8sv1alpha1.AllowlistPodAnnotation] != allowlist.Name || !consumesSecret(pod, secretnames) {
+			continue
+		}
+
+		w, ok := c.podWorkload(pod)
+		if ok && !slices.Contains(workloads, w) {
+			workloads = append(workloads, w)
+		}
+	}
+
+	// kubectl rollout restart works the same way, any change to the Pod template
+	// rolls out new Pods
+	now := time.Now().UTC().Format(time.RFC3339)
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, g8sv1alpha1.RestartedAtAnnotation, now))
+
+	var restarted []string
+	for _, w := range workloads {
+		var annotations map[string]string
+		switch w.kind {
+		case "Deployment":
+			deployment, err := c.deploymentLister.Deployments(namespace).Get(w.name)
+			if err == nil {
+				annotations = deployment.Annotations
+			}
+		case "StatefulSet":
+			statefulSet, err := c.statefulSetLister.StatefulSets(namespace).Get(w.name)
+			if err == nil {
+				annotations = statefulSet.Annotations
+			}
+		case "DaemonSet":
+			daemonSet, err := c.daemonSetLister.DaemonSets(namespace).Get(w.name)
+			if err == nil {
+				annotations = daemonSet.Annotations
+			}
+		}
+
+		if annotations[g8sv1alpha1.SkipRestartAnnotation] == "true" {
+			logger.V(4).Info("Skip restart of workload", "workload", w.String(), "namespace", namespace)
+			continue
+		}
+
+		apps := c.Client.kubeClientset.AppsV1()
+		switch w.kind {
+		case "Deployment":
+			_, err = apps.Deployments(namespace).Patch(ctx, w.name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		case "StatefulSet":
+			_, err = apps.StatefulSets(namespace).Patch(ctx, w.name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		case "DaemonSet":
+			_, err = apps.DaemonSets(namespace).Patch(ctx, w.name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		}
+		if err != nil {
+			c.recorder.Eventf(allowlist, corev1.EventTypeWarning, ErrRestartFailed, "error restarting %s in namespace %q: %s", w, namespace, err.Error())
+			continue
+		}
+
+		logger.V(4).Info("Restarted workload", "workload", w.String(), "namespace", namespace)
+		restarted = append(restarted, w.String())
+	}
+
+	if len(restarted) > 0 {
+		c.recorder.Eventf(allowlist, corev1.EventTypeNormal, SuccessRestarted, MessageWorkloadsRestarted, strings.Join(restarted, ", "), namespace, strings.Join(secretnames, ", "))
+	}
+}
+
+// consumesSecret reports whether pod references any of secretnames through a
+// volume, env var or envFrom.
+func consumesSecret(pod *corev1.Pod, secretnames []string) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.Secret != nil && slices.Contains(secretnames, v.Secret.SecretName) {
+			return true
+		}
+	}
+
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && slices.Contains(secretnames, env.ValueFrom.SecretKeyRef.Name) {
+				return true
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && slices.Contains(secretnames, envFrom.SecretRef.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// podWorkload follows the controller ownerReferences of pod up to the Deployment,
+// StatefulSet or DaemonSet that manages it, if any.
+func (c *Controller) podWorkload(pod *corev1.Pod) (workload, bool) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return workload{}, false
+	}
+
+	switch ownerRef.Kind {
+	case "StatefulSet", "DaemonSet":
+		return workload{ownerRef.Kind, ownerRef.Name}, true
+	case "ReplicaSet":
+		replicaSet, err := c.replicaSetLister.ReplicaSets(pod.Namespace).Get(ownerRef.Name)
+		if err != nil {
+			return workload{}, false
+		}
+		if ownerRef := metav1.GetControllerOf(replicaSet); ownerRef != nil && ownerRef.Kind == "Deployment" {
+			return workload{ownerRef.Kind, ownerRef.Name}, true
+		}
+	}
+
+	return workload{}, false
+}
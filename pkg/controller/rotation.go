@@ -0,0 +1,514 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// nextRotation returns when a g8s object last rotated at lastRotated is due to be
+// rotated again, or nil if it has no rotation policy.
+func nextRotation(rotation *g8sv1alpha1.RotationSpec, lastRotated time.Time) (*time.Time, error) {
+	if rotation == nil {
+		return nil, nil
+	}
+
+	if rotation.Schedule != "" {
+		schedule, err := cron.ParseStandard(rotation.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		next := schedule.Next(lastRotated)
+		return &next, nil
+	}
+
+	if rotation.Interval != nil && rotation.Interval.Duration > 0 {
+		next := lastRotated.Add(rotation.Interval.Duration)
+		return &next, nil
+	}
+
+	return nil, nil
+}
+
+// pendingRotationRequest returns the value of a g8s object's rotate-requested-at
+// annotation if it hasn't been acted upon yet, or "" otherwise.
+func pendingRotationRequest(obj metav1.Object, status g8sv1alpha1.RotationStatus) string {
+	request := obj.GetAnnotations()[g8sv1alpha1.RotateRequestedAtAnnotation]
+	if request == status.LastRotationRequest {
+		return ""
+	}
+	return request
+}
+
+// lastRotated returns when the backend Secret was last rotated according to status,
+// falling back to when the backend was created for objects that never rotated.
+func lastRotated(status g8sv1alpha1.RotationStatus, backend *corev1.Secret) metav1.Time {
+	if status.LastRotated != nil {
+		return *status.LastRotated
+	}
+	return backend.CreationTimestamp
+}
+
+// g8sObject is the API object of a g8s type
+type g8sObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// rotatedObject describes a g8s object to the rotation flow shared by every type:
+// where its rotation and history policies and its rotation status are, and how its
+// status is written. Events are recorded on object, and key is requeued on
+// workqueue when the next rotation is due.
+type rotatedObject struct {
+	key       string
+	object    g8sObject
+	g8s       internalv1alpha1.G8s
+	workqueue workqueue.RateLimitingInterface
+
+	rotation   *g8sv1alpha1.RotationSpec
+	history    *g8sv1alpha1.HistorySpec
+	status     *g8sv1alpha1.RotationStatus
+	secretType corev1.SecretType
+
+	// updateStatus writes the status of object and replaces object with what the API
+	// server returned, so that writing the status again doesn't conflict with it
+	updateStatus func(ctx context.Context) error
+
+	// generate returns the history with the generation of a requested or scheduled
+	// rotation prepended. It's g8s.Rotate unless set, e.g. to use values computed
+	// ahead of the sync.
+	generate func() (map[string]string, error)
+}
+
+// pendingRotation is what a g8s object is due for according to its rotate-requested-at
+// annotation and rotation policy
+type pendingRotation struct {
+	// request is the value of rotate-requested-at that hasn't been acted upon yet
+	request   string
+	scheduled bool
+	// held keeps requested and scheduled rotations waiting until a later sync
+	held bool
+
+	last metav1.Time
+	next *time.Time
+}
+
+// due reports whether a requested or scheduled rotation is due
+func (p pendingRotation) due() bool {
+	return (p.request != "" || p.scheduled) && !p.held
+}
+
+// checkRotation works out what o is due for. An invalid rotation policy is reported
+// as a Warning Event, o is then only rotated on request.
+func (c *Controller) checkRotation(o *rotatedObject, backend *corev1.Secret) pendingRotation {
+	p := pendingRotation{
+		request: pendingRotationRequest(o.object, *o.status),
+		last:    lastRotated(*o.status, backend),
+	}
+	next, err := nextRotation(o.rotation, p.last.Time)
+	if err != nil {
+		c.recorder.Event(o.object, corev1.EventTypeWarning, ErrInvalidRotation, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid rotation policy for '%s': %s", o.key, err.Error()))
+	}
+	p.next = next
+	p.scheduled = next != nil && !next.After(time.Now())
+	return p
+}
+
+// renewal regenerates the backend Secret of a g8s object for a reason of its type
+// while no rotation is due, e.g. to reissue a cert before it expires
+type renewal struct {
+	// generate returns the history with the renewed generation prepended
+	generate func() (map[string]string, error)
+	// message and args describe the renewal in the Event recorded once it's live,
+	// message is formatted with the name of the backend Secret before args
+	message string
+	args    []any
+}
+
+// syncRotation rotates the backend and history Secrets of o if p is due, or renews
+// them if renew isn't nil, then rolls the backend back and prunes the history as the
+// annotations and history policy of o ask for. The time of the last and next
+// rotation are recorded in the status of o, which the caller writes, and o is
+// requeued for the next one. The possibly new backend and history Secrets are
+// returned.
+func (c *Controller) syncRotation(ctx context.Context, o *rotatedObject, p pendingRotation, backend, history *corev1.Secret, renew *renewal) (*corev1.Secret, *corev1.Secret, error) {
+	var err error
+	if p.due() || renew != nil {
+		backend, history, err = c.rotate(ctx, o, &p, history, renew)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Roll the backend Secret back to an earlier generation of the history if that was
+	// requested through the rollback-to annotation
+	backend, err = c.rollback(ctx, o, backend, history)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Prune generations the history policy no longer allows for
+	history, err = c.pruneHistory(ctx, o.object, o.g8s, o.history, o.status.LiveGeneration, history)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	o.status.LastRotated = &p.last
+	o.status.NextRotation = nil
+	if p.next != nil {
+		o.status.NextRotation = &metav1.Time{Time: *p.next}
+		if !p.held {
+			o.workqueue.AddAfter(o.key, time.Until(*p.next))
+		}
+	}
+	return backend, history, nil
+}
+
+// rotate prepends a new generation to the history of o and serves it from the
+// backend Secret: the rotation p is due for, or renew otherwise. p is updated to be
+// due for nothing until its next rotation.
+func (c *Controller) rotate(ctx context.Context, o *rotatedObject, p *pendingRotation, history *corev1.Secret, renew *renewal) (*corev1.Secret, *corev1.Secret, error) {
+	klog.FromContext(ctx).V(4).Info("Rotate backend and history Secret resources", "request", p.request, "renew", !p.due())
+	o.g8s.SetHistory(history.Data)
+	generate := o.g8s.Rotate
+	if o.generate != nil {
+		generate = o.generate
+	}
+	var meta internalv1alpha1.GenerationMeta
+	switch {
+	case p.due() && p.request != "":
+		meta = generationMeta(o.object, internalv1alpha1.ReasonRequested, g8sv1alpha1.RotateRequestedAtAnnotation)
+	case p.due():
+		meta = generationMeta(o.object, internalv1alpha1.ReasonScheduled, "")
+	default:
+		generate = renew.generate
+		meta = generationMeta(o.object, internalv1alpha1.ReasonRenewed, "")
+	}
+	historyContent, err := generate()
+	if err != nil {
+		c.recorder.Event(o.object, corev1.EventTypeWarning, ErrRotationFailed, err.Error())
+		return nil, nil, err
+	}
+	internalv1alpha1.SetGenerationMeta(historyContent, 0, meta)
+
+	last := metav1.Now().Rfc3339Copy()
+	// the request stays pending until the new Secrets are in place, so that a
+	// rotation that fails is retried
+	backend, newHistory, err := c.replaceGeneration(ctx, o, historyContent, func() {
+		o.status.LastRotated = &last
+		if p.due() && p.request != "" {
+			o.status.LastRotationRequest = p.request
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case p.due() && p.request != "":
+		c.recorder.Eventf(o.object, corev1.EventTypeNormal, SuccessRotated, MessageRotationRequested, backend.Name, p.request)
+	case p.due():
+		c.recorder.Eventf(o.object, corev1.EventTypeNormal, SuccessRotated, MessageResourceRotated, backend.Name)
+	default:
+		c.recorder.Eventf(o.object, corev1.EventTypeNormal, SuccessRenewed, renew.message, append([]any{backend.Name}, renew.args...)...)
+	}
+	p.request, p.scheduled = "", false
+	p.last = last
+	p.next, _ = nextRotation(o.rotation, p.last.Time)
+	return backend, newHistory, nil
+}
+
+// replaceGeneration makes historyContent, whose generation 0 is new, the history of
+// o, pruned to its history policy, and serves generation 0 from the backend Secret.
+// status.rotationStarted is written first, so that acting on a stale copy from the
+// lister fails with a conflict instead of rotating twice. The rotation is only
+// recorded in the status of o, by record, once both Secrets are replaced, so a
+// failure partway through is retried rather than lost. A failure is reported as a
+// Warning Event on o.
+func (c *Controller) replaceGeneration(ctx context.Context, o *rotatedObject, historyContent map[string]string, record func()) (*corev1.Secret, *corev1.Secret, error) {
+	started := metav1.Now().Rfc3339Copy()
+	o.status.RotationStarted = &started
+	err := o.updateStatus(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	historyContent, _ = pruneContent(o.history, historyContent, 0)
+	backendContent := o.g8s.BackendContent(historyContent, 0)
+	backend, history, err := c.replaceSecrets(ctx, o.g8s, backendContent, historyContent, o.secretType)
+	if err != nil {
+		c.recorder.Event(o.object, corev1.EventTypeWarning, ErrRotationFailed, err.Error())
+		return nil, nil, err
+	}
+
+	o.status.RotationStarted = nil
+	o.status.LiveGeneration = 0
+	record()
+	err = o.updateStatus(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return backend, history, nil
+}
+
+// stagedHistorySuffix is appended to the name of a history Secret for the copy of its
+// new content that swapHistory writes before removing the old one
+const stagedHistorySuffix = "-staged"
+
+// replaceSecrets recreates the backend and history Secrets of a g8s object with new
+// content. Both are immutable so they can't be updated in place. The history is
+// swapped first and the backend is only touched once the new history exists, so a
+// failure partway through never loses a generation: the sync handler rebuilds a
+// missing backend from the history.
+func (c *Controller) replaceSecrets(ctx context.Context, g8s internalv1alpha1.G8s, backendContent, historyContent map[string]string, secretType corev1.SecretType) (*corev1.Secret, *corev1.Secret, error) {
+	history, err := c.swapHistory(ctx, internalv1alpha1.NewHistorySecret(g8s, historyContent))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backend, err := c.replaceBackend(ctx, g8s, backendContent, secretType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backend, history, nil
+}
+
+// swapHistory replaces a history Secret with history. The new content is first
+// written to a staged copy, so there is never a moment where neither the old nor the
+// new history exists. If the swap fails after the old history was deleted, getHistory
+// restores it from the staged copy on the next sync.
+func (c *Controller) swapHistory(ctx context.Context, history *corev1.Secret) (*corev1.Secret, error) {
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(history.Namespace)
+	staged := history.DeepCopy()
+	staged.Name += stagedHistorySuffix
+
+	// a staged copy left by an earlier failed swap is older than the live history
+	err := secrets.Delete(ctx, staged.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	_, err = secrets.Create(ctx, staged, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = secrets.Delete(ctx, history.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	history, err = secrets.Create(ctx, history, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = secrets.Delete(ctx, staged.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return history, nil
+}
+
+// getHistory returns the history Secret called name. A history that's missing
+// because swapHistory failed partway through is restored from its staged copy first,
+// so the sync handler doesn't rebuild it from the backend and lose the generations
+// before the live one. Like the lister, it returns a NotFound error if there is
+// neither.
+func (c *Controller) getHistory(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	history, err := c.secretLister.Secrets(namespace).Get(name)
+	if !errors.IsNotFound(err) {
+		return history, err
+	}
+	staged, serr := c.secretLister.Secrets(namespace).Get(name + stagedHistorySuffix)
+	if serr != nil {
+		return nil, err
+	}
+
+	klog.FromContext(ctx).V(4).Info("Restore history Secret resource from staged copy", "name", name)
+	restored := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			Labels:          staged.Labels,
+			Annotations:     staged.Annotations,
+			OwnerReferences: staged.OwnerReferences,
+		},
+		Immutable: staged.Immutable,
+		Data:      staged.Data,
+		Type:      staged.Type,
+	}
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(namespace)
+	history, err = secrets.Create(ctx, restored, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// the lister hasn't seen a history that was created since
+		history, err = secrets.Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = secrets.Delete(ctx, staged.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return history, nil
+}
+
+// replaceBackend recreates the backend Secret of a g8s object with new content,
+// leaving its history untouched.
+func (c *Controller) replaceBackend(ctx context.Context, g8s internalv1alpha1.G8s, content map[string]string, secretType corev1.SecretType) (*corev1.Secret, error) {
+	backend := internalv1alpha1.NewBackendSecret(g8s, content, secretType)
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(backend.Namespace)
+
+	err := secrets.Delete(ctx, backend.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return secrets.Create(ctx, backend, metav1.CreateOptions{})
+}
+
+// rollback restores the backend Secret of o to the generation of its history named
+// by the rollback-to annotation, if that value hasn't been acted upon yet. The status
+// of o is updated in place and the possibly new backend Secret is returned. Requests
+// that don't name a generation in the history are reported as a Warning Event and
+// otherwise ignored, since retrying them can't succeed.
+func (c *Controller) rollback(ctx context.Context, o *rotatedObject, backend, history *corev1.Secret) (*corev1.Secret, error) {
+	status := o.status
+	request, ok := o.object.GetAnnotations()[g8sv1alpha1.RollbackToAnnotation]
+	if !ok {
+		status.LastRollbackRequest = ""
+		return backend, nil
+	}
+	if request == status.LastRollbackRequest {
+		return backend, nil
+	}
+	status.LastRollbackRequest = request
+
+	historyContent := internalv1alpha1.StringData(history.Data)
+	gen, err := rollbackGeneration(request)
+	var content map[string]string
+	if err == nil && gen >= 0 {
+		content = o.g8s.BackendContent(historyContent, gen)
+	}
+	if content == nil {
+		generations := 0
+		for o.g8s.BackendContent(historyContent, generations) != nil {
+			generations++
+		}
+		c.recorder.Eventf(o.object, corev1.EventTypeWarning, ErrInvalidRollback, MessageInvalidRollback, request, generations)
+		return backend, nil
+	}
+
+	klog.FromContext(ctx).V(4).Info("Roll back backend Secret resource", "generation", gen)
+	backend, err = c.replaceBackend(ctx, o.g8s, content, o.secretType)
+	if err != nil {
+		status.LastRollbackRequest = ""
+		return nil, err
+	}
+	status.LiveGeneration = gen
+
+	c.recorder.Eventf(o.object, corev1.EventTypeNormal, SuccessRolledBack, MessageResourceRolledBack, backend.Name, gen)
+	return backend, nil
+}
+
+// rollbackGeneration returns the generation named by a value of the rollback-to
+// annotation. The value is the generation, optionally followed by @ and a timestamp,
+// e.g. 1@2024-05-01T12:00:00Z. Requests are told apart by the whole value, so the
+// timestamp is what makes rolling back to the same generation again, after a
+// rotation shifted the history, a new request.
+func rollbackGeneration(request string) (int, error) {
+	gen, _, _ := strings.Cut(request, "@")
+	return strconv.Atoi(gen)
+}
+
+// generationMeta describes a new generation of the history of object. annotation is
+// the annotation whose value requested it, if any.
+func generationMeta(object metav1.Object, reason string, annotation string) internalv1alpha1.GenerationMeta {
+	meta := internalv1alpha1.GenerationMeta{
+		CreatedAt: metav1.Now().Rfc3339Copy(),
+		Reason:    reason,
+	}
+
+	switch {
+	case annotation != "":
+		meta.TriggeredBy = annotation + "=" + object.GetAnnotations()[annotation]
+		meta.Manager = fieldManager(object, "f:"+annotation)
+	case reason == internalv1alpha1.ReasonScheduled:
+		meta.TriggeredBy = "spec.rotation"
+	default:
+		meta.Manager = fieldManager(object, "f:spec")
+	}
+	return meta
+}
+
+// fieldManager returns the manager that most recently set field of object according
+// to its managedFields, or "" if no manager owns it.
+func fieldManager(object metav1.Object, field string) string {
+	manager := ""
+	var latest time.Time
+	for _, entry := range object.GetManagedFields() {
+		if entry.FieldsV1 == nil || !strings.Contains(string(entry.FieldsV1.Raw), `"`+field+`"`) {
+			continue
+		}
+		if entry.Time == nil || !entry.Time.Time.Before(latest) {
+			manager = entry.Manager
+			if entry.Time != nil {
+				latest = entry.Time.Time
+			}
+		}
+	}
+	return manager
+}
+
+// pruneContent applies the limits of spec to the content of a history Secret, see
+// internalv1alpha1.PruneHistory.
+func pruneContent(spec *g8sv1alpha1.HistorySpec, content map[string]string, live int) (map[string]string, int) {
+	if spec == nil {
+		return content, internalv1alpha1.Generations(content)
+	}
+
+	var maxAge time.Duration
+	if spec.MaxAge != nil {
+		maxAge = spec.MaxAge.Duration
+	}
+	return internalv1alpha1.PruneHistory(content, int(spec.MaxEntries), maxAge, live, time.Now())
+}
+
+// pruneHistory drops the generations of a history Secret that fall outside the
+// limits of spec, keeping the live generation. The history is immutable so it is
+// swapped for a pruned copy when anything was pruned. The possibly new history Secret
+// is returned.
+func (c *Controller) pruneHistory(ctx context.Context, object runtime.Object, g8s internalv1alpha1.G8s, spec *g8sv1alpha1.HistorySpec, live int, history *corev1.Secret) (*corev1.Secret, error) {
+	content := internalv1alpha1.StringData(history.Data)
+	generations := internalv1alpha1.Generations(content)
+	pruned, keep := pruneContent(spec, content, live)
+	if keep == generations {
+		return history, nil
+	}
+
+	klog.FromContext(ctx).V(4).Info("Prune history Secret resource", "generations", generations, "kept", keep)
+	history, err := c.swapHistory(ctx, internalv1alpha1.NewHistorySecret(g8s, pruned))
+	if err != nil {
+		return nil, err
+	}
+
+	c.recorder.Eventf(object, corev1.EventTypeNormal, SuccessPruned, MessageHistoryPruned, history.Name, generations-keep, keep)
+	return history, nil
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -112,10 +113,12 @@ func (c *Controller) selfSignedTLSBundleSyncHandler(ctx context.Context, key str
 	backendName := "selfsignedtlsbundle-" + selfSignedTLSBundle.ObjectMeta.Name
 	historyName := "selfsignedtlsbundle-" + selfSignedTLSBundle.ObjectMeta.Name + "-history"
 
-	// Get the backend Secret, history Secret, and CSR with this namespace/name
+	// Get the backend Secret and history Secret with this namespace/name
 	backendFromLister, berr := c.secretLister.Secrets(selfSignedTLSBundle.Namespace).Get(backendName)
-	historyFromLister, herr := c.secretLister.Secrets(selfSignedTLSBundle.Namespace).Get(historyName)
-	//_, csrerr := c.certificateSigningRequestLister.Get(selfSignedTLSBundle.Name)
+	historyFromLister, herr := c.getHistory(ctx, selfSignedTLSBundle.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
 
 	// DeepCopy for safety
 	backend := backendFromLister.DeepCopy()
@@ -123,40 +126,48 @@ func (c *Controller) selfSignedTLSBundleSyncHandler(ctx context.Context, key str
 
 	g8sSelfSignedTLSBundle := internalv1alpha1.NewSelfSignedTLSBundle(selfSignedTLSBundle)
 
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sSelfSignedTLSBundle.Validate(); err != nil {
+		c.recorder.Event(selfSignedTLSBundle, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
 	// If the backend and history resources don't exist, create them
 	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
-		logger.V(4).Info("Create backend and history Secret resources and CSR")
-
-		// Create the CSR object needed to create the certificate
-		//if errors.IsNotFound(csrerr) {
-		//	go newCSR(g8sSelfSignedTLSBundle)
-		//}
-
-		historyContent := g8sSelfSignedTLSBundle.Rotate()
-		backendContent := make(map[string]string)
-		backendContent["key.pem"] = historyContent["key.pem-0"]
-		backendContent["cert.pem"] = historyContent["cert.pem-0"]
-		backendContent["cacert.pem"] = historyContent["cacert.pem-0"]
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sSelfSignedTLSBundle.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(selfSignedTLSBundle, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sSelfSignedTLSBundle.BackendContent(historyContent, 0)
 
-		backend, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSelfSignedTLSBundle, backendContent, "g8s.io/self-signed-tls-bundle"), metav1.CreateOptions{})
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSelfSignedTLSBundle, backendContent, g8sSelfSignedTLSBundle.SecretType()), metav1.CreateOptions{})
 		if err != nil {
 			return err
 		}
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSelfSignedTLSBundle, historyContent), metav1.CreateOptions{})
 	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
 		logger.V(4).Info("Create backend Secret resources from history")
-		content := make(map[string]string)
-		content["key.pem"] = string(history.Data["key.pem-0"])
-		content["cert.pem"] = string(history.Data["cert.pem-0"])
-		content["cacert.pem"] = string(history.Data["cacert.pem-0"])
-		backend, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSelfSignedTLSBundle, content, "g8s.io/self-signed-tls-bundle"), metav1.CreateOptions{})
+		content := g8sSelfSignedTLSBundle.BackendContent(internalv1alpha1.StringData(history.Data), selfSignedTLSBundle.Status.LiveGeneration)
+		if content == nil {
+			content = g8sSelfSignedTLSBundle.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSelfSignedTLSBundle, content, g8sSelfSignedTLSBundle.SecretType()), metav1.CreateOptions{})
 	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
 		logger.V(4).Info("Create history Secret resources from backend")
 		content := make(map[string]string)
-		content["key.pem-0"] = string(backend.Data["key.pem"])
-		content["cert.pem-0"] = string(backend.Data["cert.pem"])
-		content["cacert.pem-0"] = string(backend.Data["cacert.pem"])
+		content["key.pem-0"] = string(backend.Data[g8sSelfSignedTLSBundle.BackendKey("key.pem")])
+		content["cert.pem-0"] = string(backend.Data[g8sSelfSignedTLSBundle.BackendKey("cert.pem")])
+		content["cacert.pem-0"] = string(backend.Data[g8sSelfSignedTLSBundle.BackendKey("cacert.pem")])
+		if password, ok := backend.Data[internalv1alpha1.KeystorePasswordField]; ok {
+			content["keystore.password-0"] = string(password)
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(selfSignedTLSBundle, internalv1alpha1.ReasonRebuilt, ""))
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(selfSignedTLSBundle.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSelfSignedTLSBundle, content), metav1.CreateOptions{})
+		selfSignedTLSBundle.Status.LiveGeneration = 0
 	} else {
 		logger.V(4).Info("Secret resources for history and backend exist")
 	}
@@ -180,6 +191,186 @@ func (c *Controller) selfSignedTLSBundleSyncHandler(ctx context.Context, key str
 		return fmt.Errorf("%s", msg)
 	}
 
+	// Recreate the backend Secret if the output format, keystores or cert-manager
+	// annotations changed
+	if backend.Type != g8sSelfSignedTLSBundle.SecretType() || g8sSelfSignedTLSBundle.KeystoresDrifted(backend.Data) || annotationsDrifted(backend, "cert-manager.io/", g8sSelfSignedTLSBundle.BackendAnnotations()) {
+		content := g8sSelfSignedTLSBundle.BackendContent(internalv1alpha1.StringData(history.Data), selfSignedTLSBundle.Status.LiveGeneration)
+		if content != nil {
+			logger.V(4).Info("Recreate backend Secret resource in current output format", "format", selfSignedTLSBundle.Spec.OutputFormat)
+			backend, err = c.replaceBackend(ctx, g8sSelfSignedTLSBundle, content, g8sSelfSignedTLSBundle.SecretType())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The SelfSignedTLSBundle as the rotation flow shared with other types sees it,
+	// CA rotations replace its Secrets the same way
+	rotated := &rotatedObject{
+		key:        key,
+		object:     selfSignedTLSBundle,
+		g8s:        g8sSelfSignedTLSBundle,
+		workqueue:  c.selfSignedTLSBundleWorkqueue,
+		rotation:   selfSignedTLSBundle.Spec.Rotation,
+		history:    selfSignedTLSBundle.Spec.History,
+		status:     &selfSignedTLSBundle.Status.RotationStatus,
+		secretType: g8sSelfSignedTLSBundle.SecretType(),
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().SelfSignedTLSBundles(selfSignedTLSBundle.Namespace).UpdateStatus(ctx, selfSignedTLSBundle, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*selfSignedTLSBundle = *updated
+			return nil
+		},
+	}
+
+	// Move a CA rotation along. One starts when the newest CA is about to expire or
+	// is requested through the rotate-ca-requested-at annotation. Each phase lasts
+	// spec.caRotation.overlap, so that clients pick up the new CA before the cert is
+	// issued by it, and the old CA is only dropped once nothing is signed by it.
+	caRotation := selfSignedTLSBundle.Status.CARotation.DeepCopy()
+	if caRotation == nil {
+		caRotation = &g8sv1alpha1.CARotationStatus{}
+	}
+	caRequest := selfSignedTLSBundle.Annotations[g8sv1alpha1.RotateCARequestedAtAnnotation]
+	if caRequest == caRotation.LastRequest {
+		caRequest = ""
+	}
+
+	g8sSelfSignedTLSBundle.SetHistory(history.Data)
+	overlap := g8sSelfSignedTLSBundle.CAOverlap()
+	var phase g8sv1alpha1.CARotationPhase
+	switch caRotation.Phase {
+	case g8sv1alpha1.CARotationTrustPublished:
+		if at := caRotation.TrustPublishedAt; at != nil && at.Add(overlap).After(time.Now()) {
+			c.selfSignedTLSBundleWorkqueue.AddAfter(key, time.Until(at.Add(overlap)))
+		} else {
+			phase = g8sv1alpha1.CARotationLeafSwitched
+		}
+	case g8sv1alpha1.CARotationLeafSwitched:
+		if at := caRotation.LeafSwitchedAt; at != nil && at.Add(overlap).After(time.Now()) {
+			c.selfSignedTLSBundleWorkqueue.AddAfter(key, time.Until(at.Add(overlap)))
+		} else {
+			phase = g8sv1alpha1.CARotationComplete
+		}
+	default:
+		if caRequest != "" || g8sSelfSignedTLSBundle.CARotationDue(time.Now()) {
+			phase = g8sv1alpha1.CARotationTrustPublished
+		}
+	}
+
+	if phase != "" {
+		logger.V(4).Info("Advance CA rotation", "phase", phase, "request", caRequest)
+		now := metav1.Now().Rfc3339Copy()
+		caRotation.Phase = phase
+		switch phase {
+		case g8sv1alpha1.CARotationTrustPublished:
+			caRotation.TrustPublishedAt = &now
+			caRotation.LeafSwitchedAt = nil
+			caRotation.CompletedAt = nil
+			if caRequest != "" {
+				caRotation.LastRequest = caRequest
+			}
+		case g8sv1alpha1.CARotationLeafSwitched:
+			caRotation.LeafSwitchedAt = &now
+		case g8sv1alpha1.CARotationComplete:
+			caRotation.CompletedAt = &now
+		}
+
+		var historyContent map[string]string
+		meta := generationMeta(selfSignedTLSBundle, internalv1alpha1.ReasonCARotation, "")
+		switch phase {
+		case g8sv1alpha1.CARotationTrustPublished:
+			historyContent, err = g8sSelfSignedTLSBundle.PublishCA()
+			if caRequest != "" {
+				meta = generationMeta(selfSignedTLSBundle, internalv1alpha1.ReasonCARotation, g8sv1alpha1.RotateCARequestedAtAnnotation)
+			}
+		case g8sv1alpha1.CARotationLeafSwitched:
+			historyContent, err = g8sSelfSignedTLSBundle.Rotate()
+		case g8sv1alpha1.CARotationComplete:
+			historyContent = g8sSelfSignedTLSBundle.RetireCA()
+		}
+		if err != nil {
+			c.recorder.Event(selfSignedTLSBundle, corev1.EventTypeWarning, ErrRotationFailed, err.Error())
+			return err
+		}
+		if meta.TriggeredBy == "" {
+			meta.TriggeredBy = "status.caRotation.phase=" + string(phase)
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, meta)
+		backend, history, err = c.replaceGeneration(ctx, rotated, historyContent, func() {
+			selfSignedTLSBundle.Status.CARotation = caRotation
+		})
+		if err != nil {
+			return err
+		}
+
+		c.recorder.Eventf(selfSignedTLSBundle, corev1.EventTypeNormal, SuccessCARotated, MessageCARotationPhase, backend.Name, phase)
+		if phase != g8sv1alpha1.CARotationComplete {
+			c.selfSignedTLSBundleWorkqueue.AddAfter(key, overlap)
+		}
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the SelfSignedTLSBundle ask for. While a new CA is being
+	// published the cert stays signed by the old one, so requested and scheduled
+	// rotations wait for the cert to be reissued by the new CA at the end of the phase.
+	// Only an expiring cert can't wait.
+	rotation := c.checkRotation(rotated, backend)
+	rotation.held = caRotation.Phase == g8sv1alpha1.CARotationTrustPublished
+
+	// Reissue the cert in the backend Secret once it's within renewBefore of expiring,
+	// or right away if it can't be parsed
+	renew := false
+	cert, err := parseCertificate(backend.Data[g8sSelfSignedTLSBundle.BackendKey("cert.pem")])
+	if err != nil {
+		logger.V(4).Info("Cannot parse cert in backend Secret, reissuing", "err", err.Error())
+		renew = true
+	} else if !renewalTime(cert, g8sSelfSignedTLSBundle.RenewBefore()).After(time.Now()) {
+		renew = true
+	}
+
+	var renewCert *renewal
+	if renew {
+		renewCert = &renewal{
+			generate: func() (map[string]string, error) { return g8sSelfSignedTLSBundle.Rotate() },
+			message:  MessageCertificateRenewed,
+		}
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, renewCert)
+	if err != nil {
+		return err
+	}
+
+	// Record the validity of the cert now in the backend Secret and come back when
+	// it's due for renewal
+	selfSignedTLSBundle.Status.NotBefore = nil
+	selfSignedTLSBundle.Status.NotAfter = nil
+	selfSignedTLSBundle.Status.RenewalTime = nil
+	if cert, err := parseCertificate(backend.Data[g8sSelfSignedTLSBundle.BackendKey("cert.pem")]); err == nil {
+		renewal := renewalTime(cert, g8sSelfSignedTLSBundle.RenewBefore())
+		selfSignedTLSBundle.Status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+		selfSignedTLSBundle.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+		selfSignedTLSBundle.Status.RenewalTime = &metav1.Time{Time: renewal}
+		c.selfSignedTLSBundleWorkqueue.AddAfter(key, time.Until(renewal))
+	}
+
+	// The API server has to trust the webhook's CA, whichever generation it's on
+	if selfSignedTLSBundle.Namespace == "g8s" && selfSignedTLSBundle.Name == webhookTLSBundle {
+		if err := c.syncWebhookCABundle(ctx, backend.Data[g8sSelfSignedTLSBundle.BackendKey("cacert.pem")]); err != nil {
+			return err
+		}
+	}
+
+	// Only the first object to claim a signerName signs for it, the others are told
+	// which one does
+	conflict, err := c.signerConflict(selfSignedTLSBundle, selfSignedTLSBundle.Spec.SignerName, selfSignedTLSBundle.Status.SignerConflict)
+	if err != nil {
+		return err
+	}
+	selfSignedTLSBundle.Status.SignerConflict = conflict
+
 	// Finally, we update the status block of the SelfSignedTLSBundle resource to reflect the
 	// current state of the world
 	err = c.updateSelfSignedTLSBundleStatus(selfSignedTLSBundle)
@@ -0,0 +1,375 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runSSHCertificateAuthorityWorker is a long-running function that will continually call the
+// processNextSSHCertificateAuthorityWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runSSHCertificateAuthorityWorker(ctx context.Context) {
+	for c.processNextSSHCertificateAuthorityWorkItem(ctx) {
+	}
+}
+
+// processNextSSHCertificateAuthorityWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the sshCertificateAuthoritySyncHandler.
+func (c *Controller) processNextSSHCertificateAuthorityWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.sshCertificateAuthorityWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.sshCertificateAuthorityWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.sshCertificateAuthorityWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.sshCertificateAuthorityWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the sshCertificateAuthoritySyncHandler, passing it the namespace/name string of the
+		// SSHCertificateAuthority resource to be synced.
+		if err := c.sshCertificateAuthoritySyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.sshCertificateAuthorityWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.sshCertificateAuthorityWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// sshCertificateAuthoritySyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the SSHCertificateAuthority resource
+// with the current status of the resource.
+func (c *Controller) sshCertificateAuthoritySyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the SSHCertificateAuthority resource with this namespace/name
+	sshCertificateAuthorityFromLister, err := c.sshCertificateAuthorityLister.SSHCertificateAuthorities(namespace).Get(name)
+	if err != nil {
+		// The SSHCertificateAuthority resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("SSHCertificateAuthority '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	sshCertificateAuthority := sshCertificateAuthorityFromLister.DeepCopy()
+
+	backendName := "sshcertificateauthority-" + sshCertificateAuthority.ObjectMeta.Name
+	historyName := "sshcertificateauthority-" + sshCertificateAuthority.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(sshCertificateAuthority.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, sshCertificateAuthority.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sSSHCertificateAuthority := internalv1alpha1.NewSSHCertificateAuthority(sshCertificateAuthority)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sSSHCertificateAuthority.Validate(); err != nil {
+		c.recorder.Event(sshCertificateAuthority, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sSSHCertificateAuthority.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(sshCertificateAuthority, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sSSHCertificateAuthority.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(sshCertificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSSHCertificateAuthority, backendContent, sshCertificateAuthoritySecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(sshCertificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSSHCertificateAuthority, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sSSHCertificateAuthority.BackendContent(internalv1alpha1.StringData(history.Data), sshCertificateAuthority.Status.LiveGeneration)
+		if content == nil {
+			content = g8sSSHCertificateAuthority.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(sshCertificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSSHCertificateAuthority, content, sshCertificateAuthoritySecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["ca.pub-0"] = string(backend.Data["ca.pub"])
+		content["ca.key-0"] = string(backend.Data["ca.key"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(sshCertificateAuthority, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(sshCertificateAuthority.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSSHCertificateAuthority, content), metav1.CreateOptions{})
+		sshCertificateAuthority.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this SSHCertificateAuthority resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, sshCertificateAuthority) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(sshCertificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, sshCertificateAuthority) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(sshCertificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the SSHCertificateAuthority ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     sshCertificateAuthority,
+		g8s:        g8sSSHCertificateAuthority,
+		workqueue:  c.sshCertificateAuthorityWorkqueue,
+		rotation:   sshCertificateAuthority.Spec.Rotation,
+		history:    sshCertificateAuthority.Spec.History,
+		status:     &sshCertificateAuthority.Status.RotationStatus,
+		secretType: sshCertificateAuthoritySecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().SSHCertificateAuthorities(sshCertificateAuthority.Namespace).UpdateStatus(ctx, sshCertificateAuthority, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*sshCertificateAuthority = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Publish every CA key that certificates may still be signed by
+	err = c.syncSSHTrust(ctx, sshCertificateAuthority, g8sSSHCertificateAuthority, history)
+	if err != nil {
+		return err
+	}
+
+	sshCertificateAuthority.Status.Fingerprint = internalv1alpha1.SSHFingerprint(string(backend.Data["ca.pub"]))
+
+	// Finally, we update the status block of the SSHCertificateAuthority resource to reflect the
+	// current state of the world
+	err = c.updateSSHCertificateAuthorityStatus(sshCertificateAuthority)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(sshCertificateAuthority, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// sshCertificateAuthoritySecretType is the type of an SSHCertificateAuthority's backend Secret
+const sshCertificateAuthoritySecretType corev1.SecretType = "g8s.io/ssh-certificate-authority"
+
+// syncSSHTrust publishes the CA public keys of history in the trust Secret of
+// sshCertificateAuthority, replacing the Secret whenever they change. This is the
+// Secret Allowlists propagate, the backend Secret holds the CA key.
+func (c *Controller) syncSSHTrust(ctx context.Context, sshCertificateAuthority *g8sv1alpha1.SSHCertificateAuthority, g8sSSHCertificateAuthority *internalv1alpha1.SSHCertificateAuthority, history *corev1.Secret) error {
+	logger := klog.FromContext(ctx)
+	name := "sshcertificateauthority-" + sshCertificateAuthority.Name + "-trust"
+	content := g8sSSHCertificateAuthority.TrustContent(internalv1alpha1.StringData(history.Data))
+	secrets := c.Client.kubeClientset.CoreV1().Secrets(sshCertificateAuthority.Namespace)
+
+	trust, err := c.secretLister.Secrets(sshCertificateAuthority.Namespace).Get(name)
+	if err == nil {
+		if !metav1.IsControlledBy(trust, sshCertificateAuthority) {
+			msg := fmt.Sprintf(MessageResourceExists, trust.Name)
+			c.recorder.Event(sshCertificateAuthority, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf("%s", msg)
+		}
+		if string(trust.Data["ca.pub"]) == content["ca.pub"] && string(trust.Data["known_hosts"]) == content["known_hosts"] {
+			return nil
+		}
+
+		// immutable like the backend Secret, so it has to be replaced rather than updated
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	logger.V(4).Info("Publish SSH trust Secret resource", "name", name)
+	_, err = secrets.Create(ctx, internalv1alpha1.NewSSHTrustSecret(g8sSSHCertificateAuthority, internalv1alpha1.StringData(history.Data)), metav1.CreateOptions{})
+	return err
+}
+
+func (c *Controller) updateSSHCertificateAuthorityStatus(sshCertificateAuthority *g8sv1alpha1.SSHCertificateAuthority) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	sshCertificateAuthorityCopy := sshCertificateAuthority.DeepCopy()
+	sshCertificateAuthorityCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the SSHCertificateAuthority resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().SSHCertificateAuthorities(sshCertificateAuthority.Namespace).UpdateStatus(context.TODO(), sshCertificateAuthorityCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueSSHCertificateAuthority takes an SSHCertificateAuthority resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other than SSHCertificateAuthority.
+func (c *Controller) enqueueSSHCertificateAuthority(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.sshCertificateAuthorityWorkqueue.Add(key)
+}
+
+// handleSSHCertificateAuthorityObject will take any resource implementing metav1.Object and attempt
+// to find the SSHCertificateAuthority resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that SSHCertificateAuthority resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleSSHCertificateAuthorityObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by an SSHCertificateAuthority, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "SSHCertificateAuthority" {
+			return
+		}
+
+		sshCertificateAuthority, err := c.sshCertificateAuthorityLister.SSHCertificateAuthorities(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "sshCertificateAuthority", ownerRef.Name)
+			return
+		}
+
+		c.enqueueSSHCertificateAuthority(sshCertificateAuthority)
+		return
+	}
+}
+
+// Set up an event handler for when SSHCertificateAuthority and/or their backend and history Secret resources change
+func (c *Controller) setSSHCertificateAuthorityInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.sshCertificateAuthorityInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueSSHCertificateAuthority,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueSSHCertificateAuthority(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ca, ok := obj.(*g8sv1alpha1.SSHCertificateAuthority)
+			if !ok {
+				logger.Error(nil, "obj is not an SSHCertificateAuthority")
+			}
+			c.recorder.Event(ca, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when SSHCertificateAuthority backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by an SSHCertificateAuthority resource then the handler will enqueue that SSHCertificateAuthority resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleSSHCertificateAuthorityObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleSSHCertificateAuthorityObject(new)
+		},
+		DeleteFunc: c.handleSSHCertificateAuthorityObject,
+	})
+}
@@ -3,10 +3,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -113,7 +115,10 @@ func (c *Controller) sshKeyPairSyncHandler(ctx context.Context, key string) erro
 
 	// Get the backend Secret and history Secret with this namespace/name
 	backendFromLister, berr := c.secretLister.Secrets(sshKeyPair.Namespace).Get(backendName)
-	historyFromLister, herr := c.secretLister.Secrets(sshKeyPair.Namespace).Get(historyName)
+	historyFromLister, herr := c.getHistory(ctx, sshKeyPair.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
 
 	// DeepCopy for safety
 	backend := backendFromLister.DeepCopy()
@@ -121,13 +126,46 @@ func (c *Controller) sshKeyPairSyncHandler(ctx context.Context, key string) erro
 
 	g8sSSHKP := internalv1alpha1.NewSSHKeyPair(sshKeyPair)
 
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sSSHKP.Validate(); err != nil {
+		c.recorder.Event(sshKeyPair, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// A certificate is signed with the key in the backend Secret of the
+	// SSHCertificateAuthority it references. Until that exists there's nothing to do,
+	// the SSHCertificateAuthority enqueues its SSHKeyPairs whenever it changes.
+	var caPub []byte
+	if sshKeyPair.Spec.Certificate != nil {
+		caName := sshKeyPair.Spec.Certificate.CertificateAuthorityRef
+		_, err = c.sshCertificateAuthorityLister.SSHCertificateAuthorities(sshKeyPair.Namespace).Get(caName)
+		var caBackend *corev1.Secret
+		if err == nil {
+			caBackend, err = c.secretLister.Secrets(sshKeyPair.Namespace).Get("sshcertificateauthority-" + caName)
+		}
+		if err == nil {
+			err = g8sSSHKP.SetCertificateAuthority(caBackend.Data)
+		}
+		if err != nil {
+			c.recorder.Eventf(sshKeyPair, corev1.EventTypeWarning, ErrIssuerNotReady, MessageSSHIssuerNotReady, caName, err.Error())
+			logger.V(4).Info("SSHCertificateAuthority not ready", "sshCertificateAuthority", caName, "err", err.Error())
+			return nil
+		}
+		caPub = caBackend.Data["ca.pub"]
+	}
+
 	// If the backend and history resources don't exist, create them
 	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
 		logger.V(4).Info("Create backend and history Secret resources")
-		historyContent := g8sSSHKP.Rotate()
-		backendContent := make(map[string]string)
-		backendContent["ssh.pub"] = historyContent["ssh.pub-0"]
-		backendContent["ssh.key"] = historyContent["ssh.key-0"]
+		var historyContent map[string]string
+		historyContent, err = g8sSSHKP.Rotate()
+		if err != nil {
+			c.reportSSHCertificateError(ctx, sshKeyPair, err)
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(sshKeyPair, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sSSHKP.BackendContent(historyContent, 0)
 
 		backend, err = c.Client.kubeClientset.CoreV1().Secrets(sshKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSSHKP, backendContent, "g8s.io/ssh-key-pair"), metav1.CreateOptions{})
 		if err != nil {
@@ -136,16 +174,24 @@ func (c *Controller) sshKeyPairSyncHandler(ctx context.Context, key string) erro
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(sshKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSSHKP, historyContent), metav1.CreateOptions{})
 	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
 		logger.V(4).Info("Create backend Secret resources from history")
-		content := make(map[string]string)
-		content["ssh.pub"] = string(history.Data["ssh.pub-0"])
-		content["ssh.key"] = string(history.Data["ssh.key-0"])
+		content := g8sSSHKP.BackendContent(internalv1alpha1.StringData(history.Data), sshKeyPair.Status.LiveGeneration)
+		if content == nil {
+			content = g8sSSHKP.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
 		backend, err = c.Client.kubeClientset.CoreV1().Secrets(sshKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sSSHKP, content, "g8s.io/ssh-key-pair"), metav1.CreateOptions{})
 	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
 		logger.V(4).Info("Create history Secret resources from backend")
 		content := make(map[string]string)
 		content["ssh.pub-0"] = string(backend.Data["ssh.pub"])
 		content["ssh.key-0"] = string(backend.Data["ssh.key"])
+		for _, f := range []string{"ssh.passphrase", "ssh-cert.pub"} {
+			if v, ok := backend.Data[f]; ok {
+				content[f+"-0"] = string(v)
+			}
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(sshKeyPair, internalv1alpha1.ReasonRebuilt, ""))
 		history, err = c.Client.kubeClientset.CoreV1().Secrets(sshKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sSSHKP, content), metav1.CreateOptions{})
+		sshKeyPair.Status.LiveGeneration = 0
 	} else {
 		logger.V(4).Info("Secret resources for history and backend exist")
 	}
@@ -169,6 +215,80 @@ func (c *Controller) sshKeyPairSyncHandler(ctx context.Context, key string) erro
 		return fmt.Errorf("%s", msg)
 	}
 
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the SSHKeyPair ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     sshKeyPair,
+		g8s:        g8sSSHKP,
+		workqueue:  c.sshKeyPairWorkqueue,
+		rotation:   sshKeyPair.Spec.Rotation,
+		history:    sshKeyPair.Spec.History,
+		status:     &sshKeyPair.Status.RotationStatus,
+		secretType: "g8s.io/ssh-key-pair",
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().SSHKeyPairs(sshKeyPair.Namespace).UpdateStatus(ctx, sshKeyPair, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*sshKeyPair = *updated
+			return nil
+		},
+	}
+	rotation := c.checkRotation(rotated, backend)
+
+	// Reissue the certificate in the backend Secret once it's within renewBefore of
+	// expiring, right away if it's missing or can't be parsed, and whenever the
+	// SSHCertificateAuthority moved on to a new CA key. Unlike a rotation this keeps
+	// the key.
+	renew, reissue := false, false
+	if sshKeyPair.Spec.Certificate != nil {
+		cert, err := internalv1alpha1.ParseSSHCertificate(backend.Data["ssh-cert.pub"])
+		if err != nil {
+			logger.V(4).Info("Cannot parse certificate in backend Secret, reissuing", "err", err.Error())
+			renew = true
+		} else if !sshRenewalTime(cert, g8sSSHKP.CertificateRenewBefore()).After(time.Now()) {
+			renew = true
+		} else {
+			reissue = !internalv1alpha1.SSHCertificateSignedBy(cert, caPub)
+		}
+	}
+
+	var renewCert *renewal
+	if renew {
+		renewCert = &renewal{
+			generate: func() (map[string]string, error) { return g8sSSHKP.Renew() },
+			message:  MessageCertificateRenewed,
+		}
+	} else if reissue {
+		renewCert = &renewal{
+			generate: func() (map[string]string, error) { return g8sSSHKP.Renew() },
+			message:  MessageSSHCertificateReissued,
+			args:     []any{sshKeyPair.Spec.Certificate.CertificateAuthorityRef},
+		}
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, rotation, backend, history, renewCert)
+	if err != nil {
+		c.reportSSHCertificateError(ctx, sshKeyPair, err)
+		return err
+	}
+
+	sshKeyPair.Status.Fingerprint = internalv1alpha1.SSHFingerprint(string(backend.Data["ssh.pub"]))
+
+	// Record the validity of the certificate now in the backend Secret and come back
+	// when it's due for renewal
+	sshKeyPair.Status.NotBefore = nil
+	sshKeyPair.Status.NotAfter = nil
+	sshKeyPair.Status.RenewalTime = nil
+	sshKeyPair.Status.CertificateError = ""
+	if cert, err := internalv1alpha1.ParseSSHCertificate(backend.Data["ssh-cert.pub"]); err == nil {
+		renewal := sshRenewalTime(cert, g8sSSHKP.CertificateRenewBefore())
+		sshKeyPair.Status.NotBefore = &metav1.Time{Time: time.Unix(int64(cert.ValidAfter), 0)}
+		sshKeyPair.Status.NotAfter = &metav1.Time{Time: time.Unix(int64(cert.ValidBefore), 0)}
+		sshKeyPair.Status.RenewalTime = &metav1.Time{Time: renewal}
+		c.sshKeyPairWorkqueue.AddAfter(key, time.Until(renewal))
+	}
+
 	// Finally, we update the status block of the SSHKeyPair resource to reflect the
 	// current state of the world
 	err = c.updateSSHKeyPairStatus(sshKeyPair)
@@ -194,6 +314,21 @@ func (c *Controller) updateSSHKeyPairStatus(sshKeyPair *g8sv1alpha1.SSHKeyPair)
 	return err
 }
 
+// reportSSHCertificateError records err in the SSHKeyPair's status if it's the error
+// of a certificate that couldn't be issued, where it stays until one is
+func (c *Controller) reportSSHCertificateError(ctx context.Context, sshKeyPair *g8sv1alpha1.SSHKeyPair, err error) {
+	if !internalv1alpha1.IsSSHCertificateError(err) {
+		return
+	}
+
+	sshKeyPairCopy := sshKeyPair.DeepCopy()
+	sshKeyPairCopy.Status.Ready = false
+	sshKeyPairCopy.Status.CertificateError = err.Error()
+	if _, err := c.Client.g8sClientset.ApiV1alpha1().SSHKeyPairs(sshKeyPair.Namespace).UpdateStatus(ctx, sshKeyPairCopy, metav1.UpdateOptions{}); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
 // enqueueSSHKeyPair takes an SSHKeyPair resource and converts it into a namespace/name
 // string which is then put onto the workqueue. This method should *not* be
 // passed resources of any type other than SSHKeyPair.
@@ -287,4 +422,34 @@ func (c *Controller) setSSHKeyPairInformersEventHandlers(ctx context.Context) {
 		},
 		DeleteFunc: c.handleSSHKeyPairObject,
 	})
+
+	// SSHKeyPairs with a certificate are signed by the CA of the SSHCertificateAuthority
+	// they reference, so they have to be looked at again whenever it changes
+	c.sshCertificateAuthorityInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueSSHKeyPairsSignedBy,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueSSHKeyPairsSignedBy(new)
+		},
+	})
+}
+
+// enqueueSSHKeyPairsSignedBy enqueues every SSHKeyPair whose certificate references
+// the SSHCertificateAuthority obj.
+func (c *Controller) enqueueSSHKeyPairsSignedBy(obj interface{}) {
+	ca, ok := obj.(*g8sv1alpha1.SSHCertificateAuthority)
+	if !ok {
+		return
+	}
+
+	sshKeyPairs, err := c.sshKeyPairLister.SSHKeyPairs(ca.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, sshKeyPair := range sshKeyPairs {
+		if sshKeyPair.Spec.Certificate != nil && sshKeyPair.Spec.Certificate.CertificateAuthorityRef == ca.Name {
+			c.enqueueSSHKeyPair(sshKeyPair)
+		}
+	}
 }
@@ -0,0 +1,343 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runTOTPSeedWorker is a long-running function that will continually call the
+// processNextTOTPSeedWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runTOTPSeedWorker(ctx context.Context) {
+	for c.processNextTOTPSeedWorkItem(ctx) {
+	}
+}
+
+// processNextTOTPSeedWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the totpSeedSyncHandler.
+func (c *Controller) processNextTOTPSeedWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.totpSeedWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.totpSeedWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.totpSeedWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.totpSeedWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the totpSeedSyncHandler, passing it the namespace/name string of the
+		// TOTPSeed resource to be synced.
+		if err := c.totpSeedSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.totpSeedWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.totpSeedWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// totpSeedSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the TOTPSeed resource
+// with the current status of the resource.
+func (c *Controller) totpSeedSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the TOTPSeed resource with this namespace/name
+	totpSeedFromLister, err := c.totpSeedLister.TOTPSeeds(namespace).Get(name)
+	if err != nil {
+		// The TOTPSeed resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("TOTPSeed '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	totpSeed := totpSeedFromLister.DeepCopy()
+
+	backendName := "totpseed-" + totpSeed.ObjectMeta.Name
+	historyName := "totpseed-" + totpSeed.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(totpSeed.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, totpSeed.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sTOTPSeed := internalv1alpha1.NewTOTPSeed(totpSeed)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sTOTPSeed.Validate(); err != nil {
+		c.recorder.Event(totpSeed, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sTOTPSeed.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(totpSeed, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sTOTPSeed.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(totpSeed.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sTOTPSeed, backendContent, totpSeedSecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(totpSeed.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sTOTPSeed, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sTOTPSeed.BackendContent(internalv1alpha1.StringData(history.Data), totpSeed.Status.LiveGeneration)
+		if content == nil {
+			content = g8sTOTPSeed.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(totpSeed.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sTOTPSeed, content, totpSeedSecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["secret-0"] = string(backend.Data["secret"])
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(totpSeed, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(totpSeed.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sTOTPSeed, content), metav1.CreateOptions{})
+		totpSeed.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this TOTPSeed resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, totpSeed) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(totpSeed, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, totpSeed) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(totpSeed, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the TOTPSeed ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     totpSeed,
+		g8s:        g8sTOTPSeed,
+		workqueue:  c.totpSeedWorkqueue,
+		rotation:   totpSeed.Spec.Rotation,
+		history:    totpSeed.Spec.History,
+		status:     &totpSeed.Status.RotationStatus,
+		secretType: totpSeedSecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().TOTPSeeds(totpSeed.Namespace).UpdateStatus(ctx, totpSeed, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*totpSeed = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Render the otpauth URI and QR code again if the spec changed since the backend
+	// Secret was created
+	content := g8sTOTPSeed.BackendContent(internalv1alpha1.StringData(history.Data), totpSeed.Status.LiveGeneration)
+	if content != nil && content["otpauth-uri"] != string(backend.Data["otpauth-uri"]) {
+		logger.V(4).Info("Render otpauth URI of backend Secret resource")
+		backend, err = c.replaceBackend(ctx, g8sTOTPSeed, content, totpSeedSecretType)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Finally, we update the status block of the TOTPSeed resource to reflect the
+	// current state of the world
+	err = c.updateTOTPSeedStatus(totpSeed)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(totpSeed, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// totpSeedSecretType is the type of a TOTPSeed's backend Secret
+const totpSeedSecretType corev1.SecretType = "g8s.io/totp-seed"
+
+func (c *Controller) updateTOTPSeedStatus(totpSeed *g8sv1alpha1.TOTPSeed) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	totpSeedCopy := totpSeed.DeepCopy()
+	totpSeedCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the TOTPSeed resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().TOTPSeeds(totpSeed.Namespace).UpdateStatus(context.TODO(), totpSeedCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueTOTPSeed takes a TOTPSeed resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha TOTPSeed.
+func (c *Controller) enqueueTOTPSeed(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.totpSeedWorkqueue.Add(key)
+}
+
+// handleTOTPSeedObject will take any resource implementing metav1.Object and attempt
+// to find the TOTPSeed resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that TOTPSeed resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleTOTPSeedObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a TOTPSeed, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "TOTPSeed" {
+			return
+		}
+
+		totpSeed, err := c.totpSeedLister.TOTPSeeds(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "totpSeed", ownerRef.Name)
+			return
+		}
+
+		c.enqueueTOTPSeed(totpSeed)
+		return
+	}
+}
+
+// Set up an event handler for when TOTPSeed and/or their backend and history Secret resources change
+func (c *Controller) setTOTPSeedInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.totpSeedInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueTOTPSeed,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueTOTPSeed(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ts, ok := obj.(*g8sv1alpha1.TOTPSeed)
+			if !ok {
+				logger.Error(nil, "obj is not a TOTPSeed")
+			}
+			c.recorder.Event(ts, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when TOTPSeed backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a TOTPSeed resource then the handler will enqueue that TOTPSeed resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleTOTPSeedObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleTOTPSeedObject(new)
+		},
+		DeleteFunc: c.handleTOTPSeedObject,
+	})
+}
@@ -0,0 +1,418 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
+)
+
+// runWireGuardKeyPairWorker is a long-running function that will continually call the
+// processNextWireGuardKeyPairWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runWireGuardKeyPairWorker(ctx context.Context) {
+	for c.processNextWireGuardKeyPairWorkItem(ctx) {
+	}
+}
+
+// processNextWireGuardKeyPairWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the wireGuardKeyPairSyncHandler.
+func (c *Controller) processNextWireGuardKeyPairWorkItem(ctx context.Context) bool {
+	obj, shutdown := c.wireGuardKeyPairWorkqueue.Get()
+	logger := klog.FromContext(ctx)
+
+	if shutdown {
+		return false
+	}
+
+	// We wrap this block in a func so we can defer c.wireGuardKeyPairWorkqueue.Done.
+	err := func(obj interface{}) error {
+		// We call Done here so the workqueue knows we have finished
+		// processing this item. We also must remember to call Forget if we
+		// do not want this work item being re-queued. For example, we do
+		// not call Forget if a transient error occurs, instead the item is
+		// put back on the workqueue and attempted again after a back-off
+		// period.
+		defer c.wireGuardKeyPairWorkqueue.Done(obj)
+		var key string
+		var ok bool
+		// We expect strings to come off the workqueue. These are of the
+		// form namespace/name. We do this as the delayed nature of the
+		// workqueue means the items in the informer cache may actually be
+		// more up to date that when the item was initially put onto the
+		// workqueue.
+		if key, ok = obj.(string); !ok {
+			// As the item in the workqueue is actually invalid, we call
+			// Forget here else we'd go into a loop of attempting to
+			// process a work item that is invalid.
+			c.wireGuardKeyPairWorkqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		// Run the wireGuardKeyPairSyncHandler, passing it the namespace/name string of the
+		// WireGuardKeyPair resource to be synced.
+		if err := c.wireGuardKeyPairSyncHandler(ctx, key); err != nil {
+			// Put the item back on the workqueue to handle any transient errors.
+			c.wireGuardKeyPairWorkqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		// Finally, if no error occurs we Forget this item so it does not
+		// get queued again until another change happens.
+		c.wireGuardKeyPairWorkqueue.Forget(obj)
+		logger.Info("Successfully synced", "resourceName", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// wireGuardKeyPairSyncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the WireGuardKeyPair resource
+// with the current status of the resource.
+func (c *Controller) wireGuardKeyPairSyncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "resourceName", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the WireGuardKeyPair resource with this namespace/name
+	wireGuardKeyPairFromLister, err := c.wireGuardKeyPairLister.WireGuardKeyPairs(namespace).Get(name)
+	if err != nil {
+		// The WireGuardKeyPair resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("WireGuardKeyPair '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// DeepCopy for safety
+	wireGuardKeyPair := wireGuardKeyPairFromLister.DeepCopy()
+
+	backendName := "wireguardkeypair-" + wireGuardKeyPair.ObjectMeta.Name
+	historyName := "wireguardkeypair-" + wireGuardKeyPair.ObjectMeta.Name + "-history"
+
+	// Get the backend Secret and history Secret with this namespace/name
+	backendFromLister, berr := c.secretLister.Secrets(wireGuardKeyPair.Namespace).Get(backendName)
+	historyFromLister, herr := c.getHistory(ctx, wireGuardKeyPair.Namespace, historyName)
+	if herr != nil && !errors.IsNotFound(herr) {
+		return herr
+	}
+
+	// DeepCopy for safety
+	backend := backendFromLister.DeepCopy()
+	history := historyFromLister.DeepCopy()
+
+	g8sWireGuardKeyPair := internalv1alpha1.NewWireGuardKeyPair(wireGuardKeyPair)
+
+	// An invalid spec can't be fixed by retrying, so report it and wait for the next change
+	if err := g8sWireGuardKeyPair.Validate(); err != nil {
+		c.recorder.Event(wireGuardKeyPair, corev1.EventTypeWarning, ErrInvalidSpec, err.Error())
+		utilruntime.HandleError(fmt.Errorf("invalid spec for '%s': %s", key, err.Error()))
+		return nil
+	}
+
+	// The wg0.conf is rendered with the keys of the WireGuardKeyPairs in spec.peers.
+	// Peers that aren't ready yet are left out, they enqueue their peers whenever
+	// they change.
+	g8sWireGuardKeyPair.SetPeers(c.wireGuardPeers(ctx, wireGuardKeyPair))
+
+	// If the backend and history resources don't exist, create them
+	if errors.IsNotFound(berr) && errors.IsNotFound(herr) {
+		logger.V(4).Info("Create backend and history Secret resources")
+		var historyContent map[string]string
+		historyContent, err = g8sWireGuardKeyPair.Rotate()
+		if err != nil {
+			return err
+		}
+		internalv1alpha1.SetGenerationMeta(historyContent, 0, generationMeta(wireGuardKeyPair, internalv1alpha1.ReasonCreated, ""))
+		backendContent := g8sWireGuardKeyPair.BackendContent(historyContent, 0)
+
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(wireGuardKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sWireGuardKeyPair, backendContent, wireGuardKeyPairSecretType), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(wireGuardKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sWireGuardKeyPair, historyContent), metav1.CreateOptions{})
+	} else if errors.IsNotFound(berr) { // backend dne but history does, rebuild backend from history
+		logger.V(4).Info("Create backend Secret resources from history")
+		content := g8sWireGuardKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), wireGuardKeyPair.Status.LiveGeneration)
+		if content == nil {
+			content = g8sWireGuardKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), 0)
+		}
+		backend, err = c.Client.kubeClientset.CoreV1().Secrets(wireGuardKeyPair.Namespace).Create(ctx, internalv1alpha1.NewBackendSecret(g8sWireGuardKeyPair, content, wireGuardKeyPairSecretType), metav1.CreateOptions{})
+	} else if errors.IsNotFound(herr) { // backend exists but history dne, rebuild history from backend
+		logger.V(4).Info("Create history Secret resources from backend")
+		content := make(map[string]string)
+		content["private.key-0"] = string(backend.Data["private.key"])
+		content["public.key-0"] = string(backend.Data["public.key"])
+		if psk, ok := backend.Data["preshared.key"]; ok {
+			content["preshared.key-0"] = string(psk)
+		}
+		internalv1alpha1.SetGenerationMeta(content, 0, generationMeta(wireGuardKeyPair, internalv1alpha1.ReasonRebuilt, ""))
+		history, err = c.Client.kubeClientset.CoreV1().Secrets(wireGuardKeyPair.Namespace).Create(ctx, internalv1alpha1.NewHistorySecret(g8sWireGuardKeyPair, content), metav1.CreateOptions{})
+		wireGuardKeyPair.Status.LiveGeneration = 0
+	} else {
+		logger.V(4).Info("Secret resources for history and backend exist")
+	}
+
+	// If an error occurs during Get/Create, we'll requeue the item so we can
+	// attempt processing again later. This could have been caused by a
+	// temporary network failure, or any other transient reason.
+	if err != nil {
+		return err
+	}
+
+	// If the Secret is not controlled by this WireGuardKeyPair resource, we should log
+	// a warning to the event recorder and return error msg.
+	if !metav1.IsControlledBy(backend, wireGuardKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, backend.Name)
+		c.recorder.Event(wireGuardKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	} else if !metav1.IsControlledBy(history, wireGuardKeyPair) {
+		msg := fmt.Sprintf(MessageResourceExists, history.Name)
+		c.recorder.Event(wireGuardKeyPair, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+
+	// Rotate, roll back and prune the backend and history Secrets as the annotations
+	// and policies of the WireGuardKeyPair ask for
+	rotated := &rotatedObject{
+		key:        key,
+		object:     wireGuardKeyPair,
+		g8s:        g8sWireGuardKeyPair,
+		workqueue:  c.wireGuardKeyPairWorkqueue,
+		rotation:   wireGuardKeyPair.Spec.Rotation,
+		history:    wireGuardKeyPair.Spec.History,
+		status:     &wireGuardKeyPair.Status.RotationStatus,
+		secretType: wireGuardKeyPairSecretType,
+		updateStatus: func(ctx context.Context) error {
+			updated, err := c.Client.g8sClientset.ApiV1alpha1().WireGuardKeyPairs(wireGuardKeyPair.Namespace).UpdateStatus(ctx, wireGuardKeyPair, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			*wireGuardKeyPair = *updated
+			return nil
+		},
+	}
+	backend, history, err = c.syncRotation(ctx, rotated, c.checkRotation(rotated, backend), backend, history, nil)
+	if err != nil {
+		return err
+	}
+
+	// Render wg0.conf again if the peers or their keys changed since the backend
+	// Secret was created
+	content := g8sWireGuardKeyPair.BackendContent(internalv1alpha1.StringData(history.Data), wireGuardKeyPair.Status.LiveGeneration)
+	if content != nil && content["wg0.conf"] != string(backend.Data["wg0.conf"]) {
+		logger.V(4).Info("Render wg0.conf of backend Secret resource")
+		backend, err = c.replaceBackend(ctx, g8sWireGuardKeyPair, content, wireGuardKeyPairSecretType)
+		if err != nil {
+			return err
+		}
+	}
+
+	wireGuardKeyPair.Status.PublicKey = string(backend.Data["public.key"])
+
+	// Finally, we update the status block of the WireGuardKeyPair resource to reflect the
+	// current state of the world
+	err = c.updateWireGuardKeyPairStatus(wireGuardKeyPair)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Event(wireGuardKeyPair, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return nil
+}
+
+// wireGuardKeyPairSecretType is the type of a WireGuardKeyPair's backend Secret
+const wireGuardKeyPairSecretType corev1.SecretType = "g8s.io/wireguard-key-pair"
+
+func (c *Controller) updateWireGuardKeyPairStatus(wireGuardKeyPair *g8sv1alpha1.WireGuardKeyPair) error {
+	// NEVER modify objects from the store. It's a read-only, local cache.
+	// You can use DeepCopy() to make a deep copy of original object and modify this copy
+	// Or create a copy manually for better performance
+	wireGuardKeyPairCopy := wireGuardKeyPair.DeepCopy()
+	wireGuardKeyPairCopy.Status.Ready = true
+	// If the CustomResourceSubresources feature gate is not enabled,
+	// we must use Update instead of UpdateStatus to update the Status block of the WireGuardKeyPair resource.
+	// UpdateStatus will not allow changes to the Spec of the resource,
+	// which is ideal for ensuring nothing other than resource status has been updated.
+	_, err := c.Client.g8sClientset.ApiV1alpha1().WireGuardKeyPairs(wireGuardKeyPair.Namespace).UpdateStatus(context.TODO(), wireGuardKeyPairCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueWireGuardKeyPair takes a WireGuardKeyPair resource and converts it into a namespace/name
+// string which is then put onto the workqueue. This method should *not* be
+// passed resources of any type other tha WireGuardKeyPair.
+func (c *Controller) enqueueWireGuardKeyPair(obj any) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.wireGuardKeyPairWorkqueue.Add(key)
+}
+
+// handleWireGuardKeyPairObject will take any resource implementing metav1.Object and attempt
+// to find the WireGuardKeyPair resource that 'owns' it. It does this by looking at the
+// objects metadata.ownerReferences field for an appropriate OwnerReference.
+// It then enqueues that WireGuardKeyPair resource to be processed. If the object does not
+// have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleWireGuardKeyPairObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	logger := klog.FromContext(context.Background())
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		logger.V(4).Info("Recovered deleted object", "resourceName", object.GetName())
+	}
+	logger.V(4).Info("Processing object", "object", klog.KObj(object))
+	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
+		// If this object is not owned by a WireGuardKeyPair, we should not do anything more
+		// with it.
+		if ownerRef.Kind != "WireGuardKeyPair" {
+			return
+		}
+
+		wireGuardKeyPair, err := c.wireGuardKeyPairLister.WireGuardKeyPairs(object.GetNamespace()).Get(ownerRef.Name)
+		if err != nil {
+			logger.V(4).Info("Ignore orphaned object", "object", klog.KObj(object), "wireGuardKeyPair", ownerRef.Name)
+			return
+		}
+
+		c.enqueueWireGuardKeyPair(wireGuardKeyPair)
+		return
+	}
+}
+
+// Set up an event handler for when WireGuardKeyPair and/or their backend and history Secret resources change
+func (c *Controller) setWireGuardKeyPairInformersEventHandlers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	c.wireGuardKeyPairInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueWireGuardKeyPair,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueWireGuardKeyPair(new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			wg, ok := obj.(*g8sv1alpha1.WireGuardKeyPair)
+			if !ok {
+				logger.Error(nil, "obj is not a WireGuardKeyPair")
+			}
+			c.recorder.Event(wg, corev1.EventTypeNormal, SuccessDeleted, MessageResourceDeleted)
+		},
+	})
+
+	// Set up an event handler for when WireGuardKeyPair backend and history Secret resources change. This
+	// handler will lookup the owner of the given Secret, and if it is
+	// owned by a WireGuardKeyPair resource then the handler will enqueue that WireGuardKeyPair resource for
+	// processing. This way, we don't need to implement custom logic for
+	// handling Secret resources. More info on this pattern:
+	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	c.secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleWireGuardKeyPairObject,
+		UpdateFunc: func(old, new interface{}) {
+			newDepl := new.(*corev1.Secret)
+			oldDepl := old.(*corev1.Secret)
+			if newDepl.ResourceVersion == oldDepl.ResourceVersion {
+				// Periodic resync will send update events for all known Secrets.
+				// Two different versions of the same Secret will always have different ResourceVersions.
+				// This section will skip calling handleObject() if they are the same.
+				return
+			}
+			c.handleWireGuardKeyPairObject(new)
+		},
+		DeleteFunc: c.handleWireGuardKeyPairObject,
+	})
+
+	// The wg0.conf of a WireGuardKeyPair has the keys of its peers, so it has to be
+	// rendered again whenever one of them changes
+	c.wireGuardKeyPairInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueWireGuardKeyPairsPeeredWith,
+		UpdateFunc: func(old, new interface{}) {
+			c.enqueueWireGuardKeyPairsPeeredWith(new)
+		},
+		DeleteFunc: c.enqueueWireGuardKeyPairsPeeredWith,
+	})
+}
+
+// enqueueWireGuardKeyPairsPeeredWith enqueues every WireGuardKeyPair that has the
+// WireGuardKeyPair obj in spec.peers.
+func (c *Controller) enqueueWireGuardKeyPairsPeeredWith(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	peer, ok := obj.(*g8sv1alpha1.WireGuardKeyPair)
+	if !ok {
+		return
+	}
+
+	wireGuardKeyPairs, err := c.wireGuardKeyPairLister.WireGuardKeyPairs(peer.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, wireGuardKeyPair := range wireGuardKeyPairs {
+		if slices.Contains(wireGuardKeyPair.Spec.Peers, peer.Name) {
+			c.enqueueWireGuardKeyPair(wireGuardKeyPair)
+		}
+	}
+}
+
+// wireGuardPeers returns the peers of wireGuardKeyPair whose backend Secret exists,
+// in the order of spec.peers
+func (c *Controller) wireGuardPeers(ctx context.Context, wireGuardKeyPair *g8sv1alpha1.WireGuardKeyPair) []internalv1alpha1.WireGuardPeer {
+	logger := klog.FromContext(ctx)
+	var peers []internalv1alpha1.WireGuardPeer
+	for _, name := range wireGuardKeyPair.Spec.Peers {
+		peer, err := c.wireGuardKeyPairLister.WireGuardKeyPairs(wireGuardKeyPair.Namespace).Get(name)
+		if err != nil {
+			logger.V(4).Info("WireGuardKeyPair peer not ready", "peer", name, "err", err.Error())
+			continue
+		}
+		peerBackend, err := c.secretLister.Secrets(wireGuardKeyPair.Namespace).Get("wireguardkeypair-" + name)
+		if err != nil || !metav1.IsControlledBy(peerBackend, peer) {
+			logger.V(4).Info("WireGuardKeyPair peer not ready", "peer", name)
+			continue
+		}
+
+		peers = append(peers, internalv1alpha1.WireGuardPeer{
+			Name:         name,
+			PublicKey:    string(peerBackend.Data["public.key"]),
+			PresharedKey: string(peerBackend.Data["preshared.key"]),
+			Endpoint:     peer.Spec.Endpoint,
+			AllowedIPs:   peer.Spec.AllowedIPs,
+		})
+	}
+	return peers
+}
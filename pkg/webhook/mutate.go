@@ -15,10 +15,13 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	serializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	g8sv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/api.g8s.io/v1alpha1"
+	internalv1alpha1 "github.com/jrodonnell/g8s/pkg/controller/apis/internal.g8s.io/v1alpha1"
 	g8sinformers "github.com/jrodonnell/g8s/pkg/controller/generated/informers/externalversions/api.g8s.io/v1alpha1"
 )
 
@@ -26,7 +29,7 @@ type podToPatch struct {
 	corev1.Pod
 }
 
-func handleMutate(ctx context.Context, w http.ResponseWriter, r *http.Request, g8sinformer g8sinformers.AllowlistInformer) {
+func handleMutate(ctx context.Context, w http.ResponseWriter, r *http.Request, g8sinformer g8sinformers.AllowlistInformer, secretInformer coreinformers.SecretInformer) {
 	logger := klog.FromContext(ctx)
 	body, err := io.ReadAll(r.Body)
 	defer r.Body.Close()
@@ -64,10 +67,10 @@ func handleMutate(ctx context.Context, w http.ResponseWriter, r *http.Request, g
 	}
 
 	// get rules from Allowlist to determine if & how to mutate requestPod
-	allow, err := g8sinformer.Lister().Get("g8s-master")
+	allow, err := g8sinformer.Lister().Get(g8sv1alpha1.WebhookAllowlist)
 
 	if err != nil {
-		logger.Error(err, "error getting Allowlist: g8s-master")
+		logger.Error(err, "error getting Allowlist: "+g8sv1alpha1.WebhookAllowlist)
 		admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "mutation-error"}
 		admissionResponse.Allowed = false
 	}
@@ -75,12 +78,12 @@ func handleMutate(ctx context.Context, w http.ResponseWriter, r *http.Request, g
 	// generate JSONPatch to submit with AdmissionResponse
 	// targets = map[targetcontainer][]secretnames
 	targets := requestPod.findTargets(ctx, allow)
-	patch := requestPod.genPatch(targets)
+	patch := requestPod.genPatch(targets, secretInformer.Lister().Secrets("g8s"))
 	if patch != nil {
 		patch = append(patch, patchOp{
 			Op:    "add",
 			Path:  "/metadata/annotations",
-			Value: map[string]string{"g8s-webhook/allowlist": "g8s-master"},
+			Value: map[string]string{g8sv1alpha1.AllowlistPodAnnotation: allow.Name},
 		})
 	}
 
@@ -96,7 +99,7 @@ func handleMutate(ctx context.Context, w http.ResponseWriter, r *http.Request, g
 
 		admissionResponse.Patch = patchBytes
 		admissionResponse.PatchType = &patchtype
-		admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/allowlist": "g8s-master"}
+		admissionResponse.AuditAnnotations = map[string]string{g8sv1alpha1.AllowlistPodAnnotation: allow.Name}
 	}
 
 	admissionReview.Response = &admissionResponse
@@ -125,111 +128,39 @@ func (requestPod *podToPatch) findTargets(ctx context.Context, allow *g8sv1alpha
 	}
 
 	requestPodLabels := labels.Set(requestPod.ObjectMeta.Labels)
-	for _, g := range g8sv1alpha1.G8sTypes {
-		switch g {
-		case "Logins":
-			for _, g := range allow.Spec.Logins {
-				for _, t := range g.Targets {
-					var reqMatches []bool
-					selector, err := metav1.LabelSelectorAsSelector(&t.Selector)
-					if err != nil {
-						logger.Error(err, "error reading target's Selector")
-					}
-
-					requirements, err := labels.ParseToRequirements(selector.String())
-					if err != nil {
-						logger.Error(err, "error parsing Requirements from target's Selector")
-					}
-
-					for _, r := range requirements {
-						if r.Matches(requestPodLabels) {
-							reqMatches = append(reqMatches, r.Matches(requestPodLabels))
-						}
-					}
-
-					if (len(requirements) > 0) && (len(requirements) == len(reqMatches)) {
-						if t.Containers != nil { // target only containers specified in Allowlist
-							for _, tc := range t.Containers {
-								if slices.Contains(requestPodContainerNames, tc) {
-									targets[tc] = append(targets[tc], "login-"+g.Name)
-								}
-							}
-						} else {
-							for _, rpcn := range requestPodContainerNames { // target all requestPod containers
-								targets[rpcn] = append(targets[rpcn], "login-"+g.Name)
-							}
-						}
-					}
+	for _, gt := range g8sv1alpha1.G8sTypes {
+		list := g8sv1alpha1.AllowlistFields[gt]
+		for _, g := range list.Targets(&allow.Spec) {
+			secretname := list.SecretName(g.Name)
+			for _, t := range g.Targets {
+				var reqMatches []bool
+				selector, err := metav1.LabelSelectorAsSelector(&t.Selector)
+				if err != nil {
+					logger.Error(err, "error reading target's Selector")
 				}
-			}
-		case "SelfSignedTLSBundles":
-			for _, g := range allow.Spec.SelfSignedTLSBundles {
-				for _, t := range g.Targets {
-					var reqMatches []bool
-					selector, err := metav1.LabelSelectorAsSelector(&t.Selector)
-
-					if err != nil {
-						logger.Error(err, "error reading target's Selector")
-					}
-
-					requirements, err := labels.ParseToRequirements(selector.String())
-					if err != nil {
-						logger.Error(err, "error parsing Requirements from target's Selector")
-					}
 
-					for _, r := range requirements {
-						if r.Matches(requestPodLabels) {
-							reqMatches = append(reqMatches, r.Matches(requestPodLabels))
-						}
-					}
-
-					if (len(requirements) > 0) && (len(requirements) == len(reqMatches)) {
-						if t.Containers != nil { // target only containers specified in Allowlist
-							for _, tc := range t.Containers {
-								if slices.Contains(requestPodContainerNames, tc) {
-									targets[tc] = append(targets[tc], "selfsignedtlsbundle-"+g.Name)
-								}
-							}
-						} else {
-							for _, rpcn := range requestPodContainerNames { // target all requestPod containers
-								targets[rpcn] = append(targets[rpcn], "selfsignedtlsbundle-"+g.Name)
-							}
-						}
-					}
+				requirements, err := labels.ParseToRequirements(selector.String())
+				if err != nil {
+					logger.Error(err, "error parsing Requirements from target's Selector")
 				}
-			}
-		case "SSHKeyPairs":
-			for _, g := range allow.Spec.SSHKeyPairs {
-				for _, t := range g.Targets {
-					var reqMatches []bool
-					selector, err := metav1.LabelSelectorAsSelector(&t.Selector)
-					if err != nil {
-						logger.Error(err, "error reading target's Selector")
-					}
-
-					requirements, err := labels.ParseToRequirements(selector.String())
-					if err != nil {
-						logger.Error(err, "error parsing Requirements from target's Selector")
-					}
 
-					for _, r := range requirements {
-						if r.Matches(requestPodLabels) {
-							reqMatches = append(reqMatches, r.Matches(requestPodLabels))
-						}
+				for _, r := range requirements {
+					if r.Matches(requestPodLabels) {
+						reqMatches = append(reqMatches, r.Matches(requestPodLabels))
 					}
+				}
 
-					if (len(requirements) > 0) && (len(requirements) == len(reqMatches)) {
-						if t.Containers != nil { // target only containers specified in Allowlist
-							for _, tc := range t.Containers {
-								if slices.Contains(requestPodContainerNames, tc) {
-									targets[tc] = append(targets[tc], "sshkeypair-"+g.Name)
-								}
-							}
-						} else { // target all requestPod containers
-							for _, rpcn := range requestPodContainerNames {
-								targets[rpcn] = append(targets[rpcn], "sshkeypair-"+g.Name)
+				if (len(requirements) > 0) && (len(requirements) == len(reqMatches)) {
+					if t.Containers != nil { // target only containers specified in Allowlist
+						for _, tc := range t.Containers {
+							if slices.Contains(requestPodContainerNames, tc) {
+								targets[tc] = append(targets[tc], secretname)
 							}
 						}
+					} else { // target all requestPod containers
+						for _, rpcn := range requestPodContainerNames {
+							targets[rpcn] = append(targets[rpcn], secretname)
+						}
 					}
 				}
 			}
@@ -239,8 +170,13 @@ func (requestPod *podToPatch) findTargets(ctx context.Context, allow *g8sv1alpha
 	return targets
 }
 
+// envVarKey turns the characters Secret keys may have but EnvVar names shouldn't into
+// underscores
+var envVarKey = strings.NewReplacer("-", "_", ".", "_")
+
 // targets = map[targetcontainername][]secretnames
-func (requestPod *podToPatch) genPatch(targets map[string][]string) (patch []patchOp) {
+// backends looks up the backend Secrets in the g8s namespace to find their layout
+func (requestPod *podToPatch) genPatch(targets map[string][]string, backends corelisters.SecretNamespaceLister) (patch []patchOp) {
 	// skip everything and return nil if no targets
 	if len(targets) == 0 {
 		return patch
@@ -273,6 +209,280 @@ func (requestPod *podToPatch) genPatch(targets map[string][]string) (patch []pat
 
 			switch g8sType {
 			case "login":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				envVars = append(envVars, corev1.EnvVar{
+					Name: strings.ToUpper(g8sEnvVarName + "_USERNAME"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "username",
+						},
+					},
+				})
+				// hashes Secrets propagated through loginHashes have no password, and
+				// only the hashes spec.password.hashes asks for are there
+				keys := []string{"password"}
+				if backend, err := backends.Get(sn); err == nil {
+					keys = nil
+					for _, k := range append([]string{"password"}, internalv1alpha1.PasswordHashKeys...) {
+						if _, ok := backend.Data[k]; ok {
+							keys = append(keys, k)
+						}
+					}
+				}
+				for _, k := range keys {
+					envVars = append(envVars, corev1.EnvVar{
+						Name: strings.ToUpper(g8sEnvVarName + "_" + envVarKey.Replace(k)),
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: sn,
+								},
+								Key: k,
+							},
+						},
+					})
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "selfsignedtlsbundle", "certificate":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+
+				// kubernetes.io/tls bundles use the standard keys instead of *.pem, and only
+				// bundles with spec.keystores have a keystore password
+				keyKey, certKey, caCertKey := "key.pem", "cert.pem", "cacert.pem"
+				var keystorePassword bool
+				if backend, err := backends.Get(sn); err == nil {
+					if backend.Type == corev1.SecretTypeTLS {
+						keyKey, certKey, caCertKey = corev1.TLSPrivateKeyKey, corev1.TLSCertKey, "ca.crt"
+					}
+					_, keystorePassword = backend.Data[internalv1alpha1.KeystorePasswordField]
+				}
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_KEY"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: keyKey,
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_CERT"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: certKey,
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_CACERT"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: caCertKey,
+						},
+					},
+				}}...)
+				// the keystores themselves are only in the mount
+				if keystorePassword {
+					envVars = append(envVars, corev1.EnvVar{
+						Name: strings.ToUpper(g8sEnvVarName + "_KEYSTORE_PASSWORD"),
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: sn,
+								},
+								Key: internalv1alpha1.KeystorePasswordField,
+							},
+						},
+					})
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "certificateauthority":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+
+				// trust bundles only carry the CA certs
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_CACERT"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "cacert.pem",
+						},
+					},
+				}}...)
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "sshcertificateauthority":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+
+				// SSH trust Secrets only carry the CA public keys
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_CA_PUB"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "ca.pub",
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_KNOWN_HOSTS"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "known_hosts",
+						},
+					},
+				}}...)
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "jwtsigningkey":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_JWKS"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "jwks.json",
+						},
+					},
+				}}...)
+				// JWKS Secrets propagated through jwtSigningKeys only carry the public keys,
+				// backend Secrets propagated through jwtSigners the signing key as well
+				if backend, err := backends.Get(sn); err == nil {
+					if _, ok := backend.Data["key.pem"]; ok {
+						for _, k := range []string{"key.pem", "kid", "alg"} {
+							envVars = append(envVars, corev1.EnvVar{
+								Name: strings.ToUpper(g8sEnvVarName + "_" + envVarKey.Replace(k)),
+								ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: sn,
+										},
+										Key: k,
+									},
+								},
+							})
+						}
+					}
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "randomsecret":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+
+				// the keys of a RandomSecret come from its spec, so they're read off the backend Secret
+				if backend, err := backends.Get(sn); err == nil {
+					var keys []string
+					for k := range backend.Data {
+						keys = append(keys, k)
+					}
+					slices.Sort(keys)
+					for _, k := range keys {
+						envVars = append(envVars, corev1.EnvVar{
+							Name: strings.ToUpper(g8sEnvVarName + "_" + envVarKey.Replace(k)),
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: sn,
+									},
+									Key: k,
+								},
+							},
+						})
+					}
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "apitoken":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_TOKEN"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "token",
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_TOKEN_SHA256"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "token.sha256",
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_VALID_DIGESTS"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "valid-digests",
+						},
+					},
+				}}...)
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "registrycredential":
 				if !slices.Contains(allSecretNames, sn) {
 					allSecretNames = append(allSecretNames, sn)
 				}
@@ -296,44 +506,204 @@ func (requestPod *podToPatch) genPatch(targets map[string][]string) (patch []pat
 							Key: "password",
 						},
 					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_HTPASSWD"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "htpasswd",
+						},
+					},
 				}}...)
 				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
 					Name:      sn,
 					ReadOnly:  true,
 					MountPath: "/var/run/secrets/g8s/" + sn,
 				}}...)
-			case "selfsignedtlsbundle":
+			case "wireguardkeypair":
 				if !slices.Contains(allSecretNames, sn) {
 					allSecretNames = append(allSecretNames, sn)
 				}
 				envVars = append(envVars, []corev1.EnvVar{{
-					Name: strings.ToUpper(g8sEnvVarName + "_KEY"),
+					Name: strings.ToUpper(g8sEnvVarName + "_PRIVATE_KEY"),
 					ValueFrom: &corev1.EnvVarSource{
 						SecretKeyRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{
 								Name: sn,
 							},
-							Key: "key.pem",
+							Key: "private.key",
 						},
 					},
 				}, {
-					Name: strings.ToUpper(g8sEnvVarName + "_CERT"),
+					Name: strings.ToUpper(g8sEnvVarName + "_PUBLIC_KEY"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "public.key",
+						},
+					},
+				}}...)
+				// only key pairs with spec.presharedKey have a preshared key, wg0.conf is
+				// only in the mount
+				if backend, err := backends.Get(sn); err == nil {
+					if _, ok := backend.Data["preshared.key"]; ok {
+						envVars = append(envVars, corev1.EnvVar{
+							Name: strings.ToUpper(g8sEnvVarName + "_PRESHARED_KEY"),
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: sn,
+									},
+									Key: "preshared.key",
+								},
+							},
+						})
+					}
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "pgpkeypair":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_PRIVATE_KEY"),
 					ValueFrom: &corev1.EnvVarSource{
 						SecretKeyRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{
 								Name: sn,
 							},
-							Key: "cert.pem",
+							Key: "private.asc",
 						},
 					},
 				}, {
-					Name: strings.ToUpper(g8sEnvVarName + "_CACERT"),
+					Name: strings.ToUpper(g8sEnvVarName + "_PUBLIC_KEY"),
 					ValueFrom: &corev1.EnvVarSource{
 						SecretKeyRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{
 								Name: sn,
 							},
-							Key: "cacert.pem",
+							Key: "public.asc",
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_FINGERPRINT"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "fingerprint",
+						},
+					},
+				}}...)
+				// only keys with spec.passphrase have a passphrase
+				if backend, err := backends.Get(sn); err == nil {
+					if _, ok := backend.Data["passphrase"]; ok {
+						envVars = append(envVars, corev1.EnvVar{
+							Name: strings.ToUpper(g8sEnvVarName + "_PASSPHRASE"),
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: sn,
+									},
+									Key: "passphrase",
+								},
+							},
+						})
+					}
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "agekeypair":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				envVars = append(envVars, corev1.EnvVar{
+					Name: strings.ToUpper(g8sEnvVarName + "_RECIPIENT"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "recipient",
+						},
+					},
+				})
+				// recipient Secrets propagated through ageRecipients have no identity
+				if backend, err := backends.Get(sn); err == nil {
+					if _, ok := backend.Data["identity"]; ok {
+						envVars = append(envVars, corev1.EnvVar{
+							Name: strings.ToUpper(g8sEnvVarName + "_IDENTITY"),
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: sn,
+									},
+									Key: "identity",
+								},
+							},
+						})
+					}
+				}
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "totpseed":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				// qr.png is only in the mount
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_SECRET"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "secret",
+						},
+					},
+				}, {
+					Name: strings.ToUpper(g8sEnvVarName + "_OTPAUTH_URI"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "otpauth-uri",
+						},
+					},
+				}}...)
+				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
+					Name:      sn,
+					ReadOnly:  true,
+					MountPath: "/var/run/secrets/g8s/" + sn,
+				}}...)
+			case "dhparams":
+				if !slices.Contains(allSecretNames, sn) {
+					allSecretNames = append(allSecretNames, sn)
+				}
+				envVars = append(envVars, []corev1.EnvVar{{
+					Name: strings.ToUpper(g8sEnvVarName + "_DHPARAM"),
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: sn,
+							},
+							Key: "dhparam.pem",
 						},
 					},
 				}}...)
@@ -367,6 +737,27 @@ func (requestPod *podToPatch) genPatch(targets map[string][]string) (patch []pat
 						},
 					},
 				}}...)
+				// the fingerprint and passphrase are only there for keys generated since
+				// they were introduced, the passphrase only for encrypted keys and the
+				// certificate only for keys signed by an SSHCertificateAuthority
+				if backend, err := backends.Get(sn); err == nil {
+					for _, k := range [][2]string{{"ssh.fingerprint", "_FINGERPRINT"}, {"ssh.passphrase", "_PASSPHRASE"}, {"ssh-cert.pub", "_CERT"}} {
+						if _, ok := backend.Data[k[0]]; !ok {
+							continue
+						}
+						envVars = append(envVars, corev1.EnvVar{
+							Name: strings.ToUpper(g8sEnvVarName + k[1]),
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: sn,
+									},
+									Key: k[0],
+								},
+							},
+						})
+					}
+				}
 				volumeMounts = append(volumeMounts, []corev1.VolumeMount{{
 					Name:      sn,
 					ReadOnly:  true,
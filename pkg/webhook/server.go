@@ -2,9 +2,14 @@ package webhook
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/klog/v2"
 
 	g8sinformers "github.com/jrodonnell/g8s/pkg/controller/generated/informers/externalversions/api.g8s.io/v1alpha1"
@@ -16,12 +21,49 @@ type patchOp struct {
 	Value any    `json:"value,omitempty"`
 }
 
-func Serve(ctx context.Context, g8sInformer g8sinformers.AllowlistInformer) error {
+// tlsBundleSecret is the backend Secret of the SelfSignedTLSBundle that secures the webhook
+const tlsBundleSecret = "selfsignedtlsbundle-g8s-webhook"
+
+// certLoader serves the webhook's cert straight from its backend Secret, so that
+// renewed certs are picked up without restarting the webhook
+type certLoader struct {
+	secretInformer coreinformers.SecretInformer
+
+	mu              sync.Mutex
+	resourceVersion string
+	cert            *tls.Certificate
+}
+
+func (l *certLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	secret, err := l.secretInformer.Lister().Secrets("g8s").Get(tlsBundleSecret)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Secret '%s': %w", tlsBundleSecret, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cert == nil || l.resourceVersion != secret.ResourceVersion {
+		certKey, keyKey := "cert.pem", "key.pem"
+		if secret.Type == corev1.SecretTypeTLS {
+			certKey, keyKey = corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+		}
+		cert, err := tls.X509KeyPair(secret.Data[certKey], secret.Data[keyKey])
+		if err != nil {
+			return nil, fmt.Errorf("error loading cert from Secret '%s': %w", tlsBundleSecret, err)
+		}
+		l.cert = &cert
+		l.resourceVersion = secret.ResourceVersion
+	}
+
+	return l.cert, nil
+}
+
+func Serve(ctx context.Context, g8sInformer g8sinformers.AllowlistInformer, secretInformer coreinformers.SecretInformer) error {
 	logger := klog.FromContext(ctx)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", handleRoot)
 	mux.HandleFunc("/mutate", func(w http.ResponseWriter, r *http.Request) {
-		handleMutate(ctx, w, r, g8sInformer)
+		handleMutate(ctx, w, r, g8sInformer, secretInformer)
 	})
 	mux.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
 		handleValidate(w, r)
@@ -33,9 +75,12 @@ func Serve(ctx context.Context, g8sInformer g8sinformers.AllowlistInformer) erro
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
+		TLSConfig: &tls.Config{
+			GetCertificate: (&certLoader{secretInformer: secretInformer}).GetCertificate,
+		},
 	}
 
-	err := s.ListenAndServeTLS("./cert.pem", "./key.pem")
+	err := s.ListenAndServeTLS("", "")
 
 	if err != nil {
 		logger.Error(err, "Error starting webhook server")
@@ -75,63 +75,23 @@ func handleValidate(w http.ResponseWriter, r *http.Request) {
 		logger.Info("Validating Allowlist", "Allowlist.ObjectMeta.Name", allowlist.ObjectMeta.Name)
 	}
 
-	for _, g := range g8sv1alpha1.G8sTypes {
-		switch g {
-		case "Logins":
-			for ig, g := range allowlist.Spec.Logins {
-				for it, t := range g.Targets {
-					if t.Namespace == "g8s" {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Cannot target g8s namespace: .spec.%s[%d].targets[%d]", "logins", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
-
-					_, err := metav1.LabelSelectorAsSelector(&t.Selector)
-					if err != nil {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Invalid Selector: .spec.%s[%d].targets[%d]", "logins", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
+	for _, gt := range g8sv1alpha1.G8sTypes {
+		list := g8sv1alpha1.AllowlistFields[gt]
+		for ig, g := range list.Targets(&allowlist.Spec) {
+			for it, t := range g.Targets {
+				if t.Namespace == "g8s" {
+					admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
+					admissionResponse.Allowed = false
+					denied.Message = fmt.Sprintf("Cannot target g8s namespace: .spec.%s[%d].targets[%d]", list.Field, ig, it)
+					admissionResponse.Result = &denied.Status
 				}
-			}
-		case "SelfSignedTLSBundles":
-			for ig, g := range allowlist.Spec.SelfSignedTLSBundles {
-				for it, t := range g.Targets {
-					if t.Namespace == "g8s" {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Cannot target g8s namespace: .spec.%s[%d].targets[%d]", "selfSignedTLSBundles", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
-
-					_, err := metav1.LabelSelectorAsSelector(&t.Selector)
-					if err != nil {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Invalid Selector: .spec.%s[%d].targets[%d]", "selfSignedTLSBundles", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
-				}
-			}
-		case "SSHKeyPairs":
-			for ig, g := range allowlist.Spec.SSHKeyPairs {
-				for it, t := range g.Targets {
-					if t.Namespace == "g8s" {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Cannot target g8s namespace: .spec.%s[%d].targets[%d]", "sshKeyPairs", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
-
-					_, err := metav1.LabelSelectorAsSelector(&t.Selector)
-					if err != nil {
-						admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
-						admissionResponse.Allowed = false
-						denied.Message = fmt.Sprintf("Invalid Selector: .spec.%s[%d].targets[%d]", "sshKeyPairs", it, ig)
-						admissionResponse.Result = &denied.Status
-					}
+
+				_, err := metav1.LabelSelectorAsSelector(&t.Selector)
+				if err != nil {
+					admissionResponse.AuditAnnotations = map[string]string{"g8s-webhook/error": "validation-error"}
+					admissionResponse.Allowed = false
+					denied.Message = fmt.Sprintf("Invalid Selector: .spec.%s[%d].targets[%d]", list.Field, ig, it)
+					admissionResponse.Result = &denied.Status
 				}
 			}
 		}